@@ -80,6 +80,11 @@ func main() {
 	tools.RegisterFolderTools(mcpServer)
 	tools.RegisterProfileTools(mcpServer)
 	tools.RegisterDraftTools(mcpServer)
+	tools.RegisterStickerTools(mcpServer)
+	tools.RegisterBoostTools(mcpServer)
+	tools.RegisterBotTools(mcpServer)
+	tools.RegisterCallTools(mcpServer)
+	tools.RegisterCacheTools(mcpServer)
 	tools.RegisterCompoundTools(mcpServer)
 	tools.RegisterPrompts(mcpServer)
 
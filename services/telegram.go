@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -19,8 +20,10 @@ import (
 	"github.com/gotd/td/telegram"
 	"github.com/gotd/td/telegram/auth"
 	"github.com/gotd/td/telegram/message/peer"
+	"github.com/gotd/td/telegram/query"
 	"github.com/gotd/td/telegram/query/dialogs"
 	"github.com/gotd/td/tg"
+	"github.com/gotd/td/tgerr"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
@@ -203,6 +206,29 @@ func (mcpAuth) AcceptTermsOfService(_ context.Context, tos tg.HelpTermsOfService
 	return &auth.SignUpRequired{TermsOfService: tos}
 }
 
+// SessionDir returns the directory holding the session and peer cache, from
+// TELEGRAM_SESSION_DIR or ~/.telegram-mcp by default.
+func SessionDir() (string, error) {
+	if dir := os.Getenv("TELEGRAM_SESSION_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".telegram-mcp"), nil
+}
+
+// IsReady reports whether startup has finished, without blocking.
+func IsReady() bool {
+	select {
+	case <-ready:
+		return true
+	default:
+		return false
+	}
+}
+
 func StartTelegram(ctx context.Context) error {
 	defer readyOnce.Do(func() { close(ready) })
 
@@ -214,14 +240,10 @@ func StartTelegram(ctx context.Context) error {
 	appHash := os.Getenv("TELEGRAM_API_HASH")
 	phone := os.Getenv("TELEGRAM_PHONE")
 
-	sessionDir := os.Getenv("TELEGRAM_SESSION_DIR")
-	if sessionDir == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			startupErr = fmt.Errorf("cannot determine home directory: %w", err)
-			return startupErr
-		}
-		sessionDir = filepath.Join(home, ".telegram-mcp")
+	sessionDir, err := SessionDir()
+	if err != nil {
+		startupErr = err
+		return startupErr
 	}
 	if err := os.MkdirAll(sessionDir, 0700); err != nil {
 		return fmt.Errorf("create session dir: %w", err)
@@ -242,11 +264,13 @@ func StartTelegram(ctx context.Context) error {
 
 	waiter := floodwait.NewWaiter().WithCallback(func(ctx context.Context, wait floodwait.FloodWait) {
 		lg.Warn("Flood wait", zap.Duration("wait", wait.Duration))
+		recordFloodWait("middleware", wait.Duration)
 	})
 
 	client := telegram.NewClient(appID, appHash, telegram.Options{
 		Logger:         lg,
 		SessionStorage: sessionStorage,
+		Device:         telegram.DeviceConfig{LangCode: LangCode()},
 		Middlewares: []telegram.Middleware{
 			waiter,
 			ratelimit.New(rate.Every(time.Millisecond*100), 5),
@@ -275,6 +299,16 @@ func StartTelegram(ctx context.Context) error {
 
 			log.Printf("Logged in as %s (@%s)\n", self.FirstName, self.Username)
 
+			if preloadDialogs() {
+				// A failed preload only means lookups fall back to the usual on-demand resolution
+				count, err := warmPeerCache(ctx, api, peerDB)
+				if err != nil {
+					log.Printf("Preloading dialogs failed after %d peers: %v\n", count, err)
+				} else {
+					log.Printf("Preloaded %d dialog peers\n", count)
+				}
+			}
+
 			setAuthState(AuthStateAuthenticated, "")
 			readyOnce.Do(func() { close(ready) })
 
@@ -297,6 +331,151 @@ func GetInputPeerByID(ctx context.Context, chatID int64) (tg.InputPeerClass, err
 	return nil, fmt.Errorf("peer %d not found in local storage", chatID)
 }
 
+// SearchLocalPeers returns up to limit cached peers whose name, title or username contains query, case-insensitively.
+func SearchLocalPeers(ctx context.Context, query string, limit int) ([]storage.Peer, error) {
+	iter, err := PeerStorage().Iterate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("iterate peers: %w", err)
+	}
+	defer iter.Close()
+
+	query = strings.ToLower(strings.TrimPrefix(query, "@"))
+	var found []storage.Peer
+	for len(found) < limit && iter.Next(ctx) {
+		p := iter.Value()
+		if peerMatches(p, query) {
+			found = append(found, p)
+		}
+	}
+	return found, iter.Err()
+}
+
+func peerMatches(p storage.Peer, query string) bool {
+	var fields []string
+	switch {
+	case p.User != nil:
+		fields = append(fields, p.User.FirstName+" "+p.User.LastName, p.User.Username)
+		for _, u := range p.User.Usernames {
+			fields = append(fields, u.Username)
+		}
+	case p.Channel != nil:
+		fields = append(fields, p.Channel.Title, p.Channel.Username)
+		for _, u := range p.Channel.Usernames {
+			fields = append(fields, u.Username)
+		}
+	case p.Chat != nil:
+		fields = append(fields, p.Chat.Title)
+	}
+
+	for _, f := range fields {
+		if f != "" && strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// WarmPeerCache walks every dialog and stores its peer, so ID-based lookups work without
+// fetching each chat first. It returns how many dialogs were cached.
+func WarmPeerCache(ctx context.Context) (int, error) {
+	return warmPeerCache(ctx, API(), PeerStorage())
+}
+
+// warmPeerCache takes the client and storage directly so it can run during
+// startup, before API and PeerStorage stop blocking on readiness.
+func warmPeerCache(ctx context.Context, api *tg.Client, db *pebble.PeerStorage) (int, error) {
+	iter := query.GetDialogs(api).BatchSize(100).Iter()
+
+	count := 0
+	for iter.Next(ctx) {
+		elem := iter.Value()
+
+		var p storage.Peer
+		switch dlg := elem.Dialog.GetPeer().(type) {
+		case *tg.PeerUser:
+			user, ok := elem.Entities.User(dlg.UserID)
+			if !ok || !p.FromUser(user) {
+				continue
+			}
+		case *tg.PeerChat:
+			chat, ok := elem.Entities.Chat(dlg.ChatID)
+			if !ok || !p.FromChat(chat) {
+				continue
+			}
+		case *tg.PeerChannel:
+			channel, ok := elem.Entities.Channel(dlg.ChannelID)
+			if !ok || !p.FromChat(channel) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if err := db.Add(ctx, p); err != nil {
+			return count, fmt.Errorf("store peer: %w", err)
+		}
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return count, fmt.Errorf("iterate dialogs: %w", err)
+	}
+	return count, nil
+}
+
+// ExportPeers writes every cached peer to path as a JSON array and returns how many were written.
+func ExportPeers(ctx context.Context, path string) (int, error) {
+	iter, err := PeerStorage().Iterate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("iterate peers: %w", err)
+	}
+	defer iter.Close()
+
+	var peers []storage.Peer
+	if err := storage.ForEach(ctx, iter, func(p storage.Peer) error {
+		peers = append(peers, p)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	data, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("marshal peers: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return 0, fmt.Errorf("write file: %w", err)
+	}
+	return len(peers), nil
+}
+
+// ImportPeers adds the peers from a file written by ExportPeers to the cache.
+// Entries from an older storage format are skipped and counted separately.
+func ImportPeers(ctx context.Context, path string) (imported, skipped int, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read file: %w", err)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, 0, fmt.Errorf("parse file: %w", err)
+	}
+
+	db := PeerStorage()
+	for _, r := range raw {
+		var p storage.Peer
+		if err := p.UnmarshalJSON(r); err != nil {
+			skipped++
+			continue
+		}
+		if err := db.Add(ctx, p); err != nil {
+			return imported, skipped, fmt.Errorf("store peer: %w", err)
+		}
+		imported++
+	}
+	return imported, skipped, nil
+}
+
 func ResolveUsername(ctx context.Context, username string) (tg.InputPeerClass, error) {
 	username = strings.TrimPrefix(username, "@")
 	p, err := Resolver().ResolveDomain(ctx, username)
@@ -333,3 +512,107 @@ func StorePeers(ctx context.Context, chats []tg.ChatClass, users []tg.UserClass)
 		}
 	}
 }
+
+// LangCode returns the interface language sent to Telegram when connecting,
+// configurable via TELEGRAM_LANG_CODE. Telegram localizes service messages with it.
+func LangCode() string {
+	if code := os.Getenv("TELEGRAM_LANG_CODE"); code != "" {
+		return code
+	}
+	return "en"
+}
+
+const (
+	defaultFloodRetries = 3
+	maxFloodRetryWait   = 5 * time.Minute
+	maxFloodWaitEvents  = 50
+)
+
+// FloodWaitEvent is a FLOOD_WAIT Telegram imposed, recorded so callers can see throttling.
+type FloodWaitEvent struct {
+	At       time.Time
+	Duration time.Duration
+	// Source is "middleware" for waits handled transparently, or "retry" for WithFloodRetry.
+	Source string
+}
+
+var (
+	floodWaitMu     sync.Mutex
+	floodWaitEvents []FloodWaitEvent
+)
+
+func recordFloodWait(source string, d time.Duration) {
+	floodWaitMu.Lock()
+	defer floodWaitMu.Unlock()
+	floodWaitEvents = append(floodWaitEvents, FloodWaitEvent{At: time.Now(), Duration: d, Source: source})
+	if len(floodWaitEvents) > maxFloodWaitEvents {
+		floodWaitEvents = floodWaitEvents[len(floodWaitEvents)-maxFloodWaitEvents:]
+	}
+}
+
+// RecentFloodWaits returns the most recent flood waits, oldest first.
+func RecentFloodWaits() []FloodWaitEvent {
+	floodWaitMu.Lock()
+	defer floodWaitMu.Unlock()
+	return append([]FloodWaitEvent(nil), floodWaitEvents...)
+}
+
+// floodRetries returns the number of attempts for WithFloodRetry, configurable via TELEGRAM_FLOOD_RETRIES.
+func floodRetries() int {
+	n, err := strconv.Atoi(os.Getenv("TELEGRAM_FLOOD_RETRIES"))
+	if err != nil || n < 1 {
+		return defaultFloodRetries
+	}
+	return n
+}
+
+// TransferThreads returns the default number of parallel parts for file uploads and
+// downloads, configurable via TELEGRAM_TRANSFER_THREADS.
+func TransferThreads() int {
+	n, err := strconv.Atoi(os.Getenv("TELEGRAM_TRANSFER_THREADS"))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// preloadDialogs reports whether TELEGRAM_PRELOAD_DIALOGS asks to warm the peer cache on startup.
+func preloadDialogs() bool {
+	v, _ := strconv.ParseBool(os.Getenv("TELEGRAM_PRELOAD_DIALOGS"))
+	return v
+}
+
+// WithFloodRetry runs fn and retries it with backoff when it fails with a FLOOD_WAIT
+// that the middleware waiter gave up on. Other errors are returned immediately.
+func WithFloodRetry(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempts := floodRetries()
+	backoff := time.Second
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait, ok := tgerr.AsFloodWait(err)
+		if !ok {
+			return err
+		}
+		if attempt == attempts || wait+backoff > maxFloodRetryWait {
+			return fmt.Errorf("%w (rate limited by Telegram, retry in %s)", err, wait)
+		}
+
+		wait += backoff
+		backoff *= 2
+
+		recordFloodWait("retry", wait)
+		log.Printf("Flood wait on attempt %d/%d, retrying in %s\n", attempt, attempts, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
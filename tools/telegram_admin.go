@@ -2,7 +2,11 @@ package tools
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,10 +31,12 @@ type editBannedInput struct {
 }
 
 type getParticipantsInput struct {
-	Peer   string `json:"peer" jsonschema:"required"`
-	Filter string `json:"filter"`
-	Limit  int    `json:"limit"`
-	Query  string `json:"query"`
+	Peer      string `json:"peer" jsonschema:"required"`
+	Filter    string `json:"filter"`
+	Limit     int    `json:"limit"`
+	Query     string `json:"query"`
+	Format    string `json:"format"`
+	OutputDir string `json:"output_dir"`
 }
 
 type getAdminLogInput struct {
@@ -39,16 +45,29 @@ type getAdminLogInput struct {
 	Query string `json:"query"`
 }
 
+type getChatAdminsInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type getChatPermissionsInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type toggleAntiSpamInput struct {
+	Peer    string `json:"peer" jsonschema:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
 func RegisterAdminTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_edit_admin",
-			mcp.WithDescription("Edit admin rights for a user in a channel/supergroup"),
+			mcp.WithDescription("Edit admin rights for a user in a group, channel or supergroup"),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
-			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the channel/supergroup")),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the group, channel or supergroup")),
 			mcp.WithString("user_id", mcp.Required(), mcp.Description("User ID or @username of the user to promote")),
-			mcp.WithString("admin_rights", mcp.Required(), mcp.Description("Comma-separated admin rights: change_info,post_messages,edit_messages,delete_messages,ban_users,invite_users,pin_messages,manage_call,add_admins,anonymous,manage_topics,post_stories,edit_stories,delete_stories")),
-			mcp.WithString("rank", mcp.Description("Custom admin title/rank (optional)")),
+			mcp.WithString("admin_rights", mcp.Required(), mcp.Description("Comma-separated admin rights: change_info,post_messages,edit_messages,delete_messages,ban_users,invite_users,pin_messages,manage_call,add_admins,anonymous,manage_topics,post_stories,edit_stories,delete_stories. Basic groups only support admin or not: any right promotes, empty demotes")),
+			mcp.WithString("rank", mcp.Description("Custom admin title/rank (optional, channels and supergroups only)")),
 		),
 		mcp.NewTypedToolHandler(handleEditAdmin),
 	)
@@ -75,6 +94,8 @@ func RegisterAdminTools(s *server.MCPServer) {
 			mcp.WithString("filter", mcp.Description("Filter type: recent, admins, kicked, banned, bots, search (default: recent)")),
 			mcp.WithNumber("limit", mcp.Description("Maximum number of participants to return (default 20)")),
 			mcp.WithString("query", mcp.Description("Search query for kicked, banned, and search filters")),
+			mcp.WithString("format", mcp.Description("Output format: text or csv (default text). csv pages through all matching participants (up to 10000), writes a file and returns its path")),
+			mcp.WithString("output_dir", mcp.Description("Directory to write the csv file to (default ./downloads)")),
 		),
 		mcp.NewTypedToolHandler(handleGetParticipants),
 	)
@@ -90,6 +111,37 @@ func RegisterAdminTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleGetAdminLog),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_chat_admins",
+			mcp.WithDescription("List admins of a channel/supergroup with their decoded rights, rank and who promoted them"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the channel/supergroup")),
+		),
+		mcp.NewTypedToolHandler(handleGetChatAdmins),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_toggle_anti_spam",
+			mcp.WithDescription("Enable or disable Telegram's aggressive anti-spam filter in a supergroup"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the supergroup")),
+			mcp.WithBoolean("enabled", mcp.Required(), mcp.Description("true to enable anti-spam, false to disable it")),
+		),
+		mcp.NewTypedToolHandler(handleToggleAntiSpam),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_chat_permissions",
+			mcp.WithDescription("Summarize what ordinary members of a group can and cannot do (send media, polls, links, invite users, etc.), based on the chat's default permissions"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the group")),
+		),
+		mcp.NewTypedToolHandler(handleGetChatPermissions),
+	)
 }
 
 func toInputChannel(peer tg.InputPeerClass) (*tg.InputChannel, bool) {
@@ -137,6 +189,35 @@ func parseAdminRights(s string) tg.ChatAdminRights {
 	return rights
 }
 
+// adminRightsNames is the inverse of parseAdminRights, listing the granted rights by name.
+func adminRightsNames(rights tg.ChatAdminRights) []string {
+	var names []string
+	for _, r := range []struct {
+		set  bool
+		name string
+	}{
+		{rights.ChangeInfo, "change_info"},
+		{rights.PostMessages, "post_messages"},
+		{rights.EditMessages, "edit_messages"},
+		{rights.DeleteMessages, "delete_messages"},
+		{rights.BanUsers, "ban_users"},
+		{rights.InviteUsers, "invite_users"},
+		{rights.PinMessages, "pin_messages"},
+		{rights.ManageCall, "manage_call"},
+		{rights.AddAdmins, "add_admins"},
+		{rights.Anonymous, "anonymous"},
+		{rights.ManageTopics, "manage_topics"},
+		{rights.PostStories, "post_stories"},
+		{rights.EditStories, "edit_stories"},
+		{rights.DeleteStories, "delete_stories"},
+	} {
+		if r.set {
+			names = append(names, r.name)
+		}
+	}
+	return names
+}
+
 func parseBannedRights(s string, untilDate int) tg.ChatBannedRights {
 	rights := tg.ChatBannedRights{UntilDate: untilDate}
 	for _, r := range strings.Split(s, ",") {
@@ -186,7 +267,7 @@ func parseBannedRights(s string, untilDate int) tg.ChatBannedRights {
 	return rights
 }
 
-func handleEditAdmin(_ context.Context, _ mcp.CallToolRequest, input editAdminInput) (*mcp.CallToolResult, error) {
+func handleGetChatPermissions(_ context.Context, _ mcp.CallToolRequest, input getChatPermissionsInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
 	peer, err := services.ResolvePeer(tgCtx, input.Peer)
@@ -194,9 +275,113 @@ func handleEditAdmin(_ context.Context, _ mcp.CallToolRequest, input editAdminIn
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
-	inputChannel, ok := toInputChannel(peer)
-	if !ok {
-		return mcp.NewToolResultError("peer is not a channel or supergroup"), nil
+	var (
+		title    string
+		rights   tg.ChatBannedRights
+		hasRules bool
+		slowmode int
+	)
+
+	switch p := peer.(type) {
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(tgCtx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+		for _, c := range fullResult.Chats {
+			ch, ok := c.(*tg.Channel)
+			if !ok || ch.ID != p.ChannelID {
+				continue
+			}
+			if ch.Broadcast {
+				return mcp.NewToolResultText(fmt.Sprintf("%s is a broadcast channel: only admins can post.", ch.Title)), nil
+			}
+			title = ch.Title
+			rights, hasRules = ch.GetDefaultBannedRights()
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
+			slowmode, _ = full.GetSlowmodeSeconds()
+		}
+	case *tg.InputPeerChat:
+		fullResult, err := services.API().MessagesGetFullChat(tgCtx, p.ChatID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+		for _, c := range fullResult.Chats {
+			if ch, ok := c.(*tg.Chat); ok && ch.ID == p.ChatID {
+				title = ch.Title
+				rights, hasRules = ch.GetDefaultBannedRights()
+			}
+		}
+	default:
+		return mcp.NewToolResultError("peer is not a group"), nil
+	}
+
+	// Banned rights are restrictions: a set flag means members can't do it
+	permissions := []struct {
+		banned bool
+		label  string
+	}{
+		{rights.SendPlain, "Send text messages"},
+		{rights.SendPhotos, "Send photos"},
+		{rights.SendVideos, "Send videos"},
+		{rights.SendRoundvideos, "Send video messages"},
+		{rights.SendAudios, "Send music"},
+		{rights.SendVoices, "Send voice messages"},
+		{rights.SendDocs, "Send files"},
+		{rights.SendStickers, "Send stickers"},
+		{rights.SendGifs, "Send GIFs"},
+		{rights.SendGames, "Send games"},
+		{rights.SendInline, "Use inline bots"},
+		{rights.EmbedLinks, "Add link previews"},
+		{rights.SendPolls, "Send polls"},
+		{rights.InviteUsers, "Add members"},
+		{rights.PinMessages, "Pin messages"},
+		{rights.ChangeInfo, "Change chat info"},
+		{rights.ManageTopics, "Create topics"},
+	}
+
+	var can, cannot []string
+	for _, perm := range permissions {
+		if !hasRules || !perm.banned {
+			can = append(can, perm.label)
+		} else {
+			cannot = append(cannot, perm.label)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Member permissions for %s:\n", title)
+	if hasRules && rights.SendMessages {
+		b.WriteString("\nMembers can't send messages at all.\n")
+	}
+	if len(can) > 0 {
+		b.WriteString("\nMembers can:\n")
+		for _, label := range can {
+			fmt.Fprintf(&b, "  - %s\n", label)
+		}
+	}
+	if len(cannot) > 0 {
+		b.WriteString("\nMembers cannot:\n")
+		for _, label := range cannot {
+			fmt.Fprintf(&b, "  - %s\n", label)
+		}
+	}
+	if slowmode > 0 {
+		fmt.Fprintf(&b, "\nSlow mode: one message every %d seconds\n", slowmode)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleEditAdmin(_ context.Context, _ mcp.CallToolRequest, input editAdminInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
 	userPeer, err := services.ResolvePeer(tgCtx, input.UserID)
@@ -211,6 +396,28 @@ func handleEditAdmin(_ context.Context, _ mcp.CallToolRequest, input editAdminIn
 
 	rights := parseAdminRights(input.AdminRights)
 
+	// Basic groups have no granular rights: any right makes the user an admin, none demotes them
+	if chat, ok := peer.(*tg.InputPeerChat); ok {
+		isAdmin := rights != (tg.ChatAdminRights{})
+		_, err = services.API().MessagesEditChatAdmin(tgCtx, &tg.MessagesEditChatAdminRequest{
+			ChatID:  chat.ChatID,
+			UserID:  inputUser,
+			IsAdmin: isAdmin,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to edit admin rights: %v", err)), nil
+		}
+		if isAdmin {
+			return mcp.NewToolResultText("User promoted to admin of the group."), nil
+		}
+		return mcp.NewToolResultText("User demoted from admin of the group."), nil
+	}
+
+	inputChannel, ok := toInputChannel(peer)
+	if !ok {
+		return mcp.NewToolResultError("peer is not a group, channel or supergroup"), nil
+	}
+
 	_, err = services.API().ChannelsEditAdmin(tgCtx, &tg.ChannelsEditAdminRequest{
 		Channel:     inputChannel,
 		UserID:      inputUser,
@@ -224,6 +431,117 @@ func handleEditAdmin(_ context.Context, _ mcp.CallToolRequest, input editAdminIn
 	return mcp.NewToolResultText("Admin rights updated successfully."), nil
 }
 
+func handleGetChatAdmins(_ context.Context, _ mcp.CallToolRequest, input getChatAdminsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	inputChannel, ok := toInputChannel(peer)
+	if !ok {
+		return mcp.NewToolResultError("peer is not a channel or supergroup"), nil
+	}
+
+	result, err := services.API().ChannelsGetParticipants(tgCtx, &tg.ChannelsGetParticipantsRequest{
+		Channel: inputChannel,
+		Filter:  &tg.ChannelParticipantsAdmins{},
+		Limit:   100,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get admins: %v", err)), nil
+	}
+
+	participants, ok := result.(*tg.ChannelsChannelParticipants)
+	if !ok {
+		return mcp.NewToolResultError("unexpected response type"), nil
+	}
+
+	services.StorePeers(tgCtx, participants.Chats, participants.Users)
+
+	userMap := make(map[int64]*tg.User)
+	for _, u := range participants.Users {
+		user, ok := u.(*tg.User)
+		if ok {
+			userMap[user.ID] = user
+		}
+	}
+
+	writeUser := func(b *strings.Builder, userID int64) {
+		if user, ok := userMap[userID]; ok {
+			formatUserInline(b, user)
+		} else {
+			fmt.Fprintf(b, "ID: %d", userID)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Admins (%d):\n", len(participants.Participants))
+
+	for _, p := range participants.Participants {
+		switch v := p.(type) {
+		case *tg.ChannelParticipantCreator:
+			b.WriteString("\n[Creator] ")
+			writeUser(&b, v.UserID)
+			if v.Rank != "" {
+				fmt.Fprintf(&b, "\n  Rank: %s", v.Rank)
+			}
+			fmt.Fprintf(&b, "\n  Rights: %s\n", strings.Join(adminRightsNames(v.AdminRights), ", "))
+		case *tg.ChannelParticipantAdmin:
+			b.WriteString("\n[Admin] ")
+			writeUser(&b, v.UserID)
+			if v.Rank != "" {
+				fmt.Fprintf(&b, "\n  Rank: %s", v.Rank)
+			}
+			rights := adminRightsNames(v.AdminRights)
+			if len(rights) == 0 {
+				rights = []string{"none"}
+			}
+			fmt.Fprintf(&b, "\n  Rights: %s", strings.Join(rights, ", "))
+			b.WriteString("\n  Promoted")
+			if v.PromotedBy != 0 {
+				b.WriteString(" by ")
+				writeUser(&b, v.PromotedBy)
+			}
+			fmt.Fprintf(&b, " on %s", time.Unix(int64(v.Date), 0).UTC().Format("2006-01-02"))
+			if v.CanEdit {
+				b.WriteString("\n  You can edit this admin's rights")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleToggleAntiSpam(_ context.Context, _ mcp.CallToolRequest, input toggleAntiSpamInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	inputChannel, ok := toInputChannel(peer)
+	if !ok {
+		return mcp.NewToolResultError("peer is not a supergroup"), nil
+	}
+
+	_, err = services.API().ChannelsToggleAntiSpam(tgCtx, &tg.ChannelsToggleAntiSpamRequest{
+		Channel: inputChannel,
+		Enabled: input.Enabled,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to toggle anti-spam: %v", err)), nil
+	}
+
+	if input.Enabled {
+		return mcp.NewToolResultText("Anti-spam enabled successfully."), nil
+	}
+	return mcp.NewToolResultText("Anti-spam disabled successfully."), nil
+}
+
 func handleEditBanned(_ context.Context, _ mcp.CallToolRequest, input editBannedInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -293,6 +611,10 @@ func handleGetParticipants(_ context.Context, _ mcp.CallToolRequest, input getPa
 		filter = &tg.ChannelParticipantsRecent{}
 	}
 
+	if input.Format == "csv" {
+		return exportParticipantsCSV(tgCtx, inputChannel, filter, input)
+	}
+
 	result, err := services.API().ChannelsGetParticipants(tgCtx, &tg.ChannelsGetParticipantsRequest{
 		Channel: inputChannel,
 		Filter:  filter,
@@ -442,6 +764,112 @@ func handleGetAdminLog(_ context.Context, _ mcp.CallToolRequest, input getAdminL
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+// maxExportParticipants caps how many participants a csv export pages through.
+const maxExportParticipants = 10000
+
+func exportParticipantsCSV(ctx context.Context, channel *tg.InputChannel, filter tg.ChannelParticipantsFilterClass, input getParticipantsInput) (*mcp.CallToolResult, error) {
+	absDir, err := prepareOutputDir(input.OutputDir)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var all []tg.ChannelParticipantClass
+	userMap := make(map[int64]*tg.User)
+
+	for len(all) < maxExportParticipants {
+		result, err := services.API().ChannelsGetParticipants(ctx, &tg.ChannelsGetParticipantsRequest{
+			Channel: channel,
+			Filter:  filter,
+			Offset:  len(all),
+			Limit:   200,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get participants: %v", err)), nil
+		}
+
+		page, ok := result.(*tg.ChannelsChannelParticipants)
+		if !ok || len(page.Participants) == 0 {
+			break
+		}
+
+		services.StorePeers(ctx, page.Chats, page.Users)
+		for _, u := range page.Users {
+			if user, ok := u.(*tg.User); ok {
+				userMap[user.ID] = user
+			}
+		}
+
+		all = append(all, page.Participants...)
+		if len(all) >= page.Count {
+			break
+		}
+	}
+
+	name := filepath.Base(strings.TrimPrefix(input.Peer, "@"))
+	filePath := filepath.Join(absDir, fmt.Sprintf("participants_%s_%d.csv", name, time.Now().Unix()))
+	if err := writeParticipantsCSV(filePath, all, userMap); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write csv: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Exported %d participants to: %s", len(all), filePath)), nil
+}
+
+func writeParticipantsCSV(path string, participants []tg.ChannelParticipantClass, userMap map[int64]*tg.User) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"user_id", "name", "username", "role", "join_date", "rank"}); err != nil {
+		return err
+	}
+
+	for _, p := range participants {
+		var (
+			userID     int64
+			role, rank string
+			date       int
+		)
+		switch v := p.(type) {
+		case *tg.ChannelParticipant:
+			userID, role, date = v.UserID, "member", v.Date
+		case *tg.ChannelParticipantSelf:
+			userID, role, date = v.UserID, "self", v.Date
+		case *tg.ChannelParticipantCreator:
+			userID, role, rank = v.UserID, "creator", v.Rank
+		case *tg.ChannelParticipantAdmin:
+			userID, role, rank, date = v.UserID, "admin", v.Rank, v.Date
+		case *tg.ChannelParticipantBanned:
+			userID, role, date = peerToID(v.Peer), "banned", v.Date
+		case *tg.ChannelParticipantLeft:
+			userID, role = peerToID(v.Peer), "left"
+		default:
+			continue
+		}
+
+		var name, username, joinDate string
+		if user, ok := userMap[userID]; ok {
+			name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+			username = user.Username
+		}
+		if date != 0 {
+			joinDate = time.Unix(int64(date), 0).UTC().Format("2006-01-02 15:04:05")
+		}
+
+		if err := w.Write([]string{strconv.FormatInt(userID, 10), name, username, role, joinDate, rank}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
 func formatUserInline(b *strings.Builder, user *tg.User) {
 	fmt.Fprintf(b, "%s", user.FirstName)
 	if user.LastName != "" {
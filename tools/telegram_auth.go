@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -11,6 +13,10 @@ import (
 
 type authStatusInput struct{}
 
+type pingInput struct{}
+
+type getFloodWaitsInput struct{}
+
 type sendCodeInput struct {
 	Code string `json:"code" jsonschema:"required"`
 }
@@ -41,6 +47,18 @@ func RegisterAuthTools(s *server.MCPServer) {
 		mcp.WithDestructiveHintAnnotation(false),
 	)
 	s.AddTool(passwordTool, mcp.NewTypedToolHandler(handleSendPassword))
+
+	pingTool := mcp.NewTool("telegram_ping",
+		mcp.WithDescription("Diagnose the Telegram connection: startup and auth state, session directory, connected data center and API round-trip latency"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+	s.AddTool(pingTool, mcp.NewTypedToolHandler(handlePing))
+
+	floodWaitsTool := mcp.NewTool("telegram_get_flood_waits",
+		mcp.WithDescription("List recent FLOOD_WAIT rate limits imposed by Telegram, to explain slow or failing bulk operations"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+	s.AddTool(floodWaitsTool, mcp.NewTypedToolHandler(handleGetFloodWaits))
 }
 
 func handleAuthStatus(_ context.Context, _ mcp.CallToolRequest, _ authStatusInput) (*mcp.CallToolResult, error) {
@@ -52,6 +70,69 @@ func handleAuthStatus(_ context.Context, _ mcp.CallToolRequest, _ authStatusInpu
 	return mcp.NewToolResultText(msg), nil
 }
 
+func handlePing(_ context.Context, _ mcp.CallToolRequest, _ pingInput) (*mcp.CallToolResult, error) {
+	var b strings.Builder
+
+	ready := services.IsReady()
+	state := services.GetAuthState()
+	if ready {
+		b.WriteString("Client: started\n")
+	} else {
+		b.WriteString("Client: starting\n")
+	}
+	fmt.Fprintf(&b, "Auth state: %s\n", state)
+	if state == services.AuthStateError {
+		fmt.Fprintf(&b, "Error: %s\n", services.GetAuthError())
+	}
+	if dir, err := services.SessionDir(); err == nil {
+		fmt.Fprintf(&b, "Session dir: %s\n", dir)
+	}
+
+	// The API can only be called once startup finished with a logged-in session
+	if !ready || state != services.AuthStateAuthenticated {
+		b.WriteString("Connection: not available until authenticated")
+		return mcp.NewToolResultText(b.String()), nil
+	}
+
+	tgCtx := services.Context()
+	start := time.Now()
+	nearest, err := services.API().HelpGetNearestDC(tgCtx)
+	latency := time.Since(start)
+	if err != nil {
+		fmt.Fprintf(&b, "Connection: FAILED (%v)", err)
+		return mcp.NewToolResultText(b.String()), nil
+	}
+
+	b.WriteString("Connection: OK\n")
+	fmt.Fprintf(&b, "Data center: %d", nearest.ThisDC)
+	if nearest.NearestDC != nearest.ThisDC {
+		fmt.Fprintf(&b, " (nearest: %d)", nearest.NearestDC)
+	}
+	if nearest.Country != "" {
+		fmt.Fprintf(&b, "\nCountry: %s", nearest.Country)
+	}
+	fmt.Fprintf(&b, "\nLatency: %d ms", latency.Milliseconds())
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetFloodWaits(_ context.Context, _ mcp.CallToolRequest, _ getFloodWaitsInput) (*mcp.CallToolResult, error) {
+	events := services.RecentFloodWaits()
+	if len(events) == 0 {
+		return mcp.NewToolResultText("No flood waits since startup."), nil
+	}
+
+	var total time.Duration
+	var b strings.Builder
+	fmt.Fprintf(&b, "Recent flood waits (%d, newest first):\n", len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		total += e.Duration
+		fmt.Fprintf(&b, "  %s: waited %s (%s)\n", e.At.UTC().Format("2006-01-02 15:04:05"), e.Duration, e.Source)
+	}
+	fmt.Fprintf(&b, "\nTotal wait: %s", total)
+	return mcp.NewToolResultText(b.String()), nil
+}
+
 func handleSendCode(_ context.Context, _ mcp.CallToolRequest, input sendCodeInput) (*mcp.CallToolResult, error) {
 	newState, err := services.SubmitCode(input.Code)
 	if err != nil {
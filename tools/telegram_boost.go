@@ -0,0 +1,278 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gotd/td/tg"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nguyenvanduocit/telegram-mcp/services"
+)
+
+type boostChannelInput struct {
+	Peer  string `json:"peer" jsonschema:"required"`
+	Slots []int  `json:"slots"`
+}
+
+type getBoostsStatusInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type getMyBoostsInput struct{}
+
+type getPremiumGiftOptionsInput struct {
+	User string `json:"user"`
+}
+
+// zeroDecimalCurrencies are currencies whose amounts have no fractional part.
+var zeroDecimalCurrencies = map[string]bool{
+	"JPY": true, "KRW": true, "VND": true, "CLP": true, "ISK": true, "UGX": true, "PYG": true, "XTR": true,
+}
+
+// threeDecimalCurrencies are currencies whose amounts are counted in thousandths.
+var threeDecimalCurrencies = map[string]bool{
+	"BHD": true, "IQD": true, "JOD": true, "KWD": true, "LYD": true, "OMR": true, "TND": true,
+}
+
+func RegisterBoostTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool("telegram_boost_channel",
+			mcp.WithDescription("Boost a channel or supergroup with one of your boost slots (Telegram Premium)"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Channel ID or @username to boost")),
+			mcp.WithArray("slots", mcp.WithNumberItems(), mcp.Description("Boost slots to use. Slots boosting another channel are moved. Default: a free slot")),
+		),
+		mcp.NewTypedToolHandler(handleBoostChannel),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_boosts_status",
+			mcp.WithDescription("Get a channel's boost level, boost count, and how many boosts the next level needs"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Channel ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleGetBoostsStatus),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_my_boosts",
+			mcp.WithDescription("List your boost slots: which channels you are boosting, when each boost expires, and which slots are free"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetMyBoosts),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_premium_gift_options",
+			mcp.WithDescription("List the Telegram Premium gift options (duration and price), optionally for a specific user. Read-only: the purchase itself must be completed in an official Telegram app"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("user", mcp.Description("User ID or @username you want to gift Premium to (optional)")),
+		),
+		mcp.NewTypedToolHandler(handleGetPremiumGiftOptions),
+	)
+}
+
+func handleBoostChannel(_ context.Context, _ mcp.CallToolRequest, input boostChannelInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+	if _, ok := peer.(*tg.InputPeerChannel); !ok {
+		return mcp.NewToolResultError("only channels and supergroups can be boosted"), nil
+	}
+
+	slots := input.Slots
+	if len(slots) == 0 {
+		mine, err := services.API().PremiumGetMyBoosts(tgCtx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get your boosts: %v", err)), nil
+		}
+		for _, b := range mine.MyBoosts {
+			if b.Peer == nil {
+				slots = []int{b.Slot}
+				break
+			}
+		}
+		if len(slots) == 0 {
+			if len(mine.MyBoosts) == 0 {
+				return mcp.NewToolResultError("you have no boost slots (boosting requires Telegram Premium)"), nil
+			}
+			return mcp.NewToolResultError("all boost slots are in use; pass slots to move a boost from another channel"), nil
+		}
+	}
+
+	req := &tg.PremiumApplyBoostRequest{Peer: peer}
+	req.SetSlots(slots)
+
+	result, err := services.API().PremiumApplyBoost(tgCtx, req)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to boost: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	var b strings.Builder
+	b.WriteString("Boost applied successfully.\n\n")
+	formatMyBoosts(tgCtx, &b, result.MyBoosts)
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetBoostsStatus(_ context.Context, _ mcp.CallToolRequest, input getBoostsStatusInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	status, err := services.API().PremiumGetBoostsStatus(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get boosts status: %v", err)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Level: %d\n", status.Level)
+	fmt.Fprintf(&b, "Boosts: %d", status.Boosts)
+	if status.GiftBoosts > 0 {
+		fmt.Fprintf(&b, " (%d from gifts)", status.GiftBoosts)
+	}
+	b.WriteString("\n")
+	if next, ok := status.GetNextLevelBoosts(); ok {
+		fmt.Fprintf(&b, "Next Level: %d boosts needed for level %d (%d more)\n", next, status.Level+1, next-status.Boosts)
+	} else {
+		b.WriteString("Next Level: maximum level reached\n")
+	}
+	if status.MyBoost {
+		slots := make([]string, len(status.MyBoostSlots))
+		for i, slot := range status.MyBoostSlots {
+			slots[i] = strconv.Itoa(slot)
+		}
+		fmt.Fprintf(&b, "Your Boost: yes (slots %s)\n", strings.Join(slots, ", "))
+	} else {
+		b.WriteString("Your Boost: no\n")
+	}
+	if status.BoostURL != "" {
+		fmt.Fprintf(&b, "Boost Link: %s\n", status.BoostURL)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetMyBoosts(_ context.Context, _ mcp.CallToolRequest, _ getMyBoostsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	result, err := services.API().PremiumGetMyBoosts(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get your boosts: %v", err)), nil
+	}
+
+	if len(result.MyBoosts) == 0 {
+		return mcp.NewToolResultText("You have no boost slots (boosting requires Telegram Premium)."), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	var b strings.Builder
+	formatMyBoosts(tgCtx, &b, result.MyBoosts)
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetPremiumGiftOptions(_ context.Context, _ mcp.CallToolRequest, input getPremiumGiftOptionsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	var b strings.Builder
+
+	if input.User != "" {
+		peer, err := services.ResolvePeer(tgCtx, input.User)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve user: %v", err)), nil
+		}
+		inputUser, ok := toInputUser(peer)
+		if !ok {
+			return mcp.NewToolResultError("the provided identifier does not resolve to a user"), nil
+		}
+
+		users, err := services.API().UsersGetUsers(tgCtx, []tg.InputUserClass{inputUser})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get user: %v", err)), nil
+		}
+		for _, u := range users {
+			user, ok := u.(*tg.User)
+			if !ok {
+				continue
+			}
+			if user.Bot {
+				return mcp.NewToolResultError("Premium can't be gifted to bots"), nil
+			}
+			b.WriteString("Recipient: ")
+			formatUserInline(&b, user)
+			if user.Premium {
+				b.WriteString(" (already has Premium; a gift extends it)")
+			}
+			b.WriteString("\n\n")
+		}
+	}
+
+	options, err := services.API().PaymentsGetPremiumGiftCodeOptions(tgCtx, &tg.PaymentsGetPremiumGiftCodeOptionsRequest{})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get gift options: %v", err)), nil
+	}
+
+	b.WriteString("Premium gift options:\n")
+	n := 0
+	for _, o := range options {
+		// Options for more than one user are giveaway packages
+		if o.Users != 1 {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "  %d month(s): %s\n", o.Months, formatAmount(o.Amount, o.Currency))
+	}
+	if n == 0 {
+		return mcp.NewToolResultText("No Premium gift options available."), nil
+	}
+
+	b.WriteString("\nComplete the purchase in an official Telegram app: open the recipient's profile and choose Gift Premium.\n")
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// formatAmount formats a price given in the currency's smallest units, e.g. 399 USD as "3.99 USD".
+func formatAmount(amount int64, currency string) string {
+	if zeroDecimalCurrencies[currency] {
+		return fmt.Sprintf("%d %s", amount, currency)
+	}
+	if threeDecimalCurrencies[currency] {
+		return fmt.Sprintf("%d.%03d %s", amount/1000, amount%1000, currency)
+	}
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+}
+
+// formatMyBoosts writes one line per boost slot with the boosted peer and expiry date.
+func formatMyBoosts(ctx context.Context, b *strings.Builder, boosts []tg.MyBoost) {
+	fmt.Fprintf(b, "Boost slots (%d):\n", len(boosts))
+	for _, boost := range boosts {
+		fmt.Fprintf(b, "  Slot %d: ", boost.Slot)
+		if boost.Peer == nil {
+			b.WriteString("free")
+		} else {
+			fmt.Fprintf(b, "%s (ID: %d), expires %s", senderLabel(ctx, boost.Peer), peerToID(boost.Peer),
+				time.Unix(int64(boost.Expires), 0).UTC().Format("2006-01-02 15:04:05"))
+		}
+		if until, ok := boost.GetCooldownUntilDate(); ok && until > int(time.Now().Unix()) {
+			fmt.Fprintf(b, ", can be reassigned after %s", time.Unix(int64(until), 0).UTC().Format("2006-01-02 15:04:05"))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gotd/td/tg"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nguyenvanduocit/telegram-mcp/services"
+)
+
+type getSimilarBotsInput struct {
+	Bot string `json:"bot" jsonschema:"required"`
+}
+
+type sendInlineResultInput struct {
+	Bot      string `json:"bot" jsonschema:"required"`
+	Query    string `json:"query"`
+	Peer     string `json:"peer" jsonschema:"required"`
+	ResultID string `json:"result_id"`
+}
+
+func RegisterBotTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool("telegram_get_similar_bots",
+			mcp.WithDescription("Find bots similar to a given bot, to discover helper bots for a task"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("bot", mcp.Required(), mcp.Description("Bot ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleGetSimilarBots),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_send_inline_result",
+			mcp.WithDescription("Use an inline bot such as @wiki or @gif. Without result_id, lists the bot's results for the query; with result_id, sends that result to the chat"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("bot", mcp.Required(), mcp.Description("Inline bot @username, e.g. @gif")),
+			mcp.WithString("query", mcp.Description("Inline query text")),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username where the result is used")),
+			mcp.WithString("result_id", mcp.Description("ID of the result to send, from a previous call with the same query")),
+		),
+		mcp.NewTypedToolHandler(handleSendInlineResult),
+	)
+}
+
+func handleGetSimilarBots(_ context.Context, _ mcp.CallToolRequest, input getSimilarBotsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Bot)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve bot: %v", err)), nil
+	}
+	inputUser, ok := toInputUser(peer)
+	if !ok {
+		return mcp.NewToolResultError("the provided identifier does not resolve to a bot"), nil
+	}
+
+	result, err := services.API().BotsGetBotRecommendations(tgCtx, inputUser)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get similar bots: %v", err)), nil
+	}
+
+	users := result.GetUsers()
+	if len(users) == 0 {
+		return mcp.NewToolResultText("No similar bots found."), nil
+	}
+
+	services.StorePeers(tgCtx, nil, users)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Similar bots (%d):\n", len(users))
+	for _, u := range users {
+		user, ok := u.(*tg.User)
+		if !ok {
+			continue
+		}
+		b.WriteString("\n")
+		formatUser(&b, user)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleSendInlineResult(_ context.Context, _ mcp.CallToolRequest, input sendInlineResultInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	botPeer, err := services.ResolvePeer(tgCtx, input.Bot)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve bot: %v", err)), nil
+	}
+	bot, ok := toInputUser(botPeer)
+	if !ok {
+		return mcp.NewToolResultError("the provided identifier does not resolve to a bot"), nil
+	}
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	// Query IDs expire quickly, so the query is always re-run before sending
+	results, err := services.API().MessagesGetInlineBotResults(tgCtx, &tg.MessagesGetInlineBotResultsRequest{
+		Bot:   bot,
+		Peer:  peer,
+		Query: input.Query,
+	})
+	if err != nil {
+		if tg.IsBotInlineDisabled(err) {
+			return mcp.NewToolResultError("this bot does not support inline mode"), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to query inline bot: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, nil, results.Users)
+
+	if input.ResultID == "" {
+		return mcp.NewToolResultText(formatInlineResults(input.Query, results)), nil
+	}
+
+	found := false
+	for _, r := range results.Results {
+		if r.GetID() == input.ResultID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return mcp.NewToolResultError(fmt.Sprintf("result %q not found for this query; results may have changed, list them again", input.ResultID)), nil
+	}
+
+	var result tg.UpdatesClass
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		var err error
+		result, err = services.API().MessagesSendInlineBotResult(ctx, &tg.MessagesSendInlineBotResultRequest{
+			Peer:     peer,
+			RandomID: randomID(),
+			QueryID:  results.QueryID,
+			ID:       input.ResultID,
+		})
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to send inline result: %v", err)), nil
+	}
+
+	if ids := sentMessageIDs(result); len(ids) > 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Inline result sent successfully (message ID: %d).", ids[0])), nil
+	}
+	return mcp.NewToolResultText("Inline result sent successfully."), nil
+}
+
+func formatInlineResults(query string, results *tg.MessagesBotResults) string {
+	if len(results.Results) == 0 {
+		return fmt.Sprintf("No inline results for %q.", query)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Inline results for %q (%d):\n", query, len(results.Results))
+	for _, rc := range results.Results {
+		switch r := rc.(type) {
+		case *tg.BotInlineResult:
+			fmt.Fprintf(&b, "\n  ID: %s, Type: %s", r.ID, r.Type)
+			if r.Title != "" {
+				fmt.Fprintf(&b, ", Title: %s", r.Title)
+			}
+			if r.Description != "" {
+				fmt.Fprintf(&b, "\n    %s", truncateText(r.Description, 200))
+			}
+			if r.URL != "" {
+				fmt.Fprintf(&b, "\n    %s", r.URL)
+			}
+		case *tg.BotInlineMediaResult:
+			fmt.Fprintf(&b, "\n  ID: %s, Type: %s", r.ID, r.Type)
+			if r.Title != "" {
+				fmt.Fprintf(&b, ", Title: %s", r.Title)
+			}
+			if r.Description != "" {
+				fmt.Fprintf(&b, "\n    %s", truncateText(r.Description, 200))
+			}
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nguyenvanduocit/telegram-mcp/services"
+)
+
+type warmPeerCacheInput struct{}
+
+type exportPeerCacheInput struct {
+	OutputDir string `json:"output_dir"`
+}
+
+type importPeerCacheInput struct {
+	FilePath string `json:"file_path" jsonschema:"required"`
+}
+
+func RegisterCacheTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool("telegram_warm_peer_cache",
+			mcp.WithDescription("Load every dialog into the local peer cache so chats and users can be resolved by numeric ID. Run this when a tool reports \"peer not found in local storage\""),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleWarmPeerCache),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_export_peer_cache",
+			mcp.WithDescription("Export the local peer cache (IDs, access hashes, names) to a JSON file, to carry peer resolution over to another machine. The file grants access to your chats by ID: keep it private"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("output_dir", mcp.Description("Directory to write the file to (default ./downloads)")),
+		),
+		mcp.NewTypedToolHandler(handleExportPeerCache),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_import_peer_cache",
+			mcp.WithDescription("Import peers from a file written by telegram_export_peer_cache, so chats can be resolved by ID without fetching them first"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("file_path", mcp.Required(), mcp.Description("Absolute path to the exported JSON file")),
+		),
+		mcp.NewTypedToolHandler(handleImportPeerCache),
+	)
+}
+
+func handleWarmPeerCache(_ context.Context, _ mcp.CallToolRequest, _ warmPeerCacheInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	count, err := services.WarmPeerCache(tgCtx)
+	if err != nil {
+		if count == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to warm peer cache: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Cached %d peers before stopping: %v", count, err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Cached %d peers from your dialogs.", count)), nil
+}
+
+func handleExportPeerCache(_ context.Context, _ mcp.CallToolRequest, input exportPeerCacheInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	absDir, err := prepareOutputDir(input.OutputDir)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	path := filepath.Join(absDir, fmt.Sprintf("peers_%d.json", time.Now().Unix()))
+	count, err := services.ExportPeers(tgCtx, path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to export peer cache: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Exported %d peers to %s", count, path)), nil
+}
+
+func handleImportPeerCache(_ context.Context, _ mcp.CallToolRequest, input importPeerCacheInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	cleanPath, err := cleanInputPath(input.FilePath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	imported, skipped, err := services.ImportPeers(tgCtx, cleanPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to import peer cache: %v", err)), nil
+	}
+
+	if skipped > 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Imported %d peers (%d outdated entries skipped).", imported, skipped)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Imported %d peers.", imported)), nil
+}
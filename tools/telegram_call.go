@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gotd/td/tg"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nguyenvanduocit/telegram-mcp/services"
+)
+
+type getGroupCallInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type createGroupCallInput struct {
+	Peer         string `json:"peer" jsonschema:"required"`
+	Title        string `json:"title"`
+	ScheduleDate int    `json:"schedule_date"`
+}
+
+type endGroupCallInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+func RegisterCallTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool("telegram_get_group_call",
+			mcp.WithDescription("Get the state of a group's active voice chat: title, participant count, recording and schedule"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Group or channel ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleGetGroupCall),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_create_group_call",
+			mcp.WithDescription("Start a voice chat in a group or channel, or schedule one for later (requires admin rights to manage calls)"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Group or channel ID or @username")),
+			mcp.WithString("title", mcp.Description("Voice chat title")),
+			mcp.WithNumber("schedule_date", mcp.Description("Unix timestamp to schedule the voice chat instead of starting it now")),
+		),
+		mcp.NewTypedToolHandler(handleCreateGroupCall),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_end_group_call",
+			mcp.WithDescription("End the active voice chat in a group or channel for everyone (requires admin rights to manage calls)"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Group or channel ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleEndGroupCall),
+	)
+}
+
+// groupCallForPeer returns the voice chat attached to a group, or nil if none is active
+func groupCallForPeer(ctx context.Context, peer tg.InputPeerClass) (tg.InputGroupCallClass, error) {
+	var full tg.ChatFullClass
+	switch p := peer.(type) {
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(ctx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
+		if err != nil {
+			return nil, err
+		}
+		services.StorePeers(ctx, fullResult.Chats, fullResult.Users)
+		full = fullResult.FullChat
+	case *tg.InputPeerChat:
+		fullResult, err := services.API().MessagesGetFullChat(ctx, p.ChatID)
+		if err != nil {
+			return nil, err
+		}
+		services.StorePeers(ctx, fullResult.Chats, fullResult.Users)
+		full = fullResult.FullChat
+	default:
+		return nil, fmt.Errorf("peer is not a group or channel")
+	}
+
+	call, ok := full.GetCall()
+	if !ok {
+		return nil, nil
+	}
+	return call, nil
+}
+
+func handleGetGroupCall(_ context.Context, _ mcp.CallToolRequest, input getGroupCallInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	inputCall, err := groupCallForPeer(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+	}
+	if inputCall == nil {
+		return mcp.NewToolResultText("No active voice chat in this chat."), nil
+	}
+
+	result, err := services.API().PhoneGetGroupCall(tgCtx, &tg.PhoneGetGroupCallRequest{
+		Call:  inputCall,
+		Limit: 1,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get group call: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	call, ok := result.Call.(*tg.GroupCall)
+	if !ok {
+		return mcp.NewToolResultText("The voice chat has ended."), nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Voice chat:\n")
+	if call.Title != "" {
+		fmt.Fprintf(&b, "  Title: %s\n", call.Title)
+	}
+	fmt.Fprintf(&b, "  Call ID: %d\n", call.ID)
+	fmt.Fprintf(&b, "  Participants: %d\n", call.ParticipantsCount)
+	if start, ok := call.GetRecordStartDate(); ok {
+		fmt.Fprintf(&b, "  Recording: yes (since %s)\n", time.Unix(int64(start), 0).UTC().Format("2006-01-02 15:04:05"))
+	} else {
+		b.WriteString("  Recording: no\n")
+	}
+	if date, ok := call.GetScheduleDate(); ok {
+		fmt.Fprintf(&b, "  Scheduled for: %s\n", time.Unix(int64(date), 0).UTC().Format("2006-01-02 15:04:05"))
+	}
+	if call.RtmpStream {
+		b.WriteString("  Live stream: yes\n")
+	}
+	if call.JoinMuted {
+		b.WriteString("  New participants join muted\n")
+	}
+	if call.UnmutedVideoLimit > 0 {
+		fmt.Fprintf(&b, "  Video: %d/%d\n", call.UnmutedVideoCount, call.UnmutedVideoLimit)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleCreateGroupCall(_ context.Context, _ mcp.CallToolRequest, input createGroupCallInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+	switch peer.(type) {
+	case *tg.InputPeerChannel, *tg.InputPeerChat:
+	default:
+		return mcp.NewToolResultError("peer is not a group or channel"), nil
+	}
+
+	req := &tg.PhoneCreateGroupCallRequest{
+		Peer:     peer,
+		RandomID: int(int32(randomID())),
+	}
+	if input.Title != "" {
+		req.SetTitle(input.Title)
+	}
+	if input.ScheduleDate > 0 {
+		req.SetScheduleDate(input.ScheduleDate)
+	}
+
+	result, err := services.API().PhoneCreateGroupCall(tgCtx, req)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create group call: %v", err)), nil
+	}
+
+	callID := groupCallIDFromUpdates(result)
+	var b strings.Builder
+	if input.ScheduleDate > 0 {
+		fmt.Fprintf(&b, "Voice chat scheduled for %s", time.Unix(int64(input.ScheduleDate), 0).UTC().Format("2006-01-02 15:04:05"))
+	} else {
+		b.WriteString("Voice chat started")
+	}
+	if callID != 0 {
+		fmt.Fprintf(&b, " (call ID: %d)", callID)
+	}
+	b.WriteString(".")
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleEndGroupCall(_ context.Context, _ mcp.CallToolRequest, input endGroupCallInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	inputCall, err := groupCallForPeer(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+	}
+	if inputCall == nil {
+		return mcp.NewToolResultText("No active voice chat in this chat."), nil
+	}
+
+	if _, err := services.API().PhoneDiscardGroupCall(tgCtx, inputCall); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to end group call: %v", err)), nil
+	}
+
+	if call, ok := inputCall.(*tg.InputGroupCall); ok {
+		return mcp.NewToolResultText(fmt.Sprintf("Voice chat ended (call ID: %d).", call.ID)), nil
+	}
+	return mcp.NewToolResultText("Voice chat ended."), nil
+}
+
+func groupCallIDFromUpdates(result tg.UpdatesClass) int64 {
+	var updates []tg.UpdateClass
+	switch u := result.(type) {
+	case *tg.Updates:
+		updates = u.Updates
+	case *tg.UpdatesCombined:
+		updates = u.Updates
+	}
+
+	for _, update := range updates {
+		if u, ok := update.(*tg.UpdateGroupCall); ok {
+			return u.Call.GetID()
+		}
+	}
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gotd/td/tg"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,16 +22,32 @@ type getChatInput struct {
 }
 
 type searchChatsInput struct {
-	Query string `json:"query" jsonschema:"required"`
-	Limit int    `json:"limit"`
+	Query          string `json:"query" jsonschema:"required"`
+	Limit          int    `json:"limit"`
+	IncludeSimilar bool   `json:"include_similar"`
 }
 
 type joinChatInput struct {
 	Peer string `json:"peer" jsonschema:"required"`
 }
 
+type checkInviteStatusInput struct {
+	Link string `json:"link" jsonschema:"required"`
+}
+
+type resolveInviteLinkInput struct {
+	Link string `json:"link" jsonschema:"required"`
+}
+
 type leaveChatInput struct {
-	Peer string `json:"peer" jsonschema:"required"`
+	Peer   string `json:"peer" jsonschema:"required"`
+	Delete bool   `json:"delete"`
+}
+
+type deleteChatInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	Mode      string `json:"mode" jsonschema:"required"`
+	JustClear bool   `json:"just_clear"`
 }
 
 type createGroupInput struct {
@@ -43,11 +60,37 @@ type toggleDialogPinInput struct {
 	Pinned *bool  `json:"pinned"`
 }
 
+type getPinnedDialogsInput struct {
+	FolderID int `json:"folder_id"`
+}
+
+type reorderPinnedDialogsInput struct {
+	Peers    string `json:"peers" jsonschema:"required"`
+	FolderID int    `json:"folder_id"`
+}
+
 type markDialogUnreadInput struct {
 	Peer   string `json:"peer" jsonschema:"required"`
 	Unread *bool  `json:"unread"`
 }
 
+type getOnlineCountInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type getChannelRecommendationsInput struct {
+	Peer string `json:"peer"`
+}
+
+type getUnreadMarksInput struct {
+	Limit int `json:"limit"`
+}
+
+type setDefaultSendAsInput struct {
+	Peer   string `json:"peer" jsonschema:"required"`
+	SendAs string `json:"send_as" jsonschema:"required"`
+}
+
 func RegisterChatTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_list_chats",
@@ -77,6 +120,7 @@ func RegisterChatTools(s *server.MCPServer) {
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithString("query", mcp.Required(), mcp.Description("Search query string")),
 			mcp.WithNumber("limit", mcp.Description("Maximum number of results (default 20)")),
+			mcp.WithBoolean("include_similar", mcp.Description("Also list channels similar to the top channel result (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleSearchChats),
 	)
@@ -91,16 +135,49 @@ func RegisterChatTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleJoinChat),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_check_invite_status",
+			mcp.WithDescription("Check an invite link: whether you've joined, can join directly, or need admin approval"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("link", mcp.Required(), mcp.Description("Invite link (https://t.me/+... or https://t.me/joinchat/...)")),
+		),
+		mcp.NewTypedToolHandler(handleCheckInviteStatus),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_resolve_invite_link",
+			mcp.WithDescription("Resolve an invite link of a chat you've joined (or can preview) to its peer ID, so it can be passed to other tools"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("link", mcp.Required(), mcp.Description("Invite link (https://t.me/+... or https://t.me/joinchat/...)")),
+		),
+		mcp.NewTypedToolHandler(handleResolveInviteLink),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_leave_chat",
-			mcp.WithDescription("Leave a chat or channel"),
+			mcp.WithDescription("Leave a chat or channel, optionally deleting the conversation from the dialog list"),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(true),
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithBoolean("delete", mcp.Description("Also delete the chat history so the dialog disappears from the chat list (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleLeaveChat),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_delete_chat",
+			mcp.WithDescription("Delete a basic group for everyone (creator only), or delete/clear a chat for yourself only"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithString("mode", mcp.Required(), mcp.Description("group: delete the basic group and its history for all members (creator only); for_me: delete the chat from your dialog list only")),
+			mcp.WithBoolean("just_clear", mcp.Description("With mode for_me, clear the history but keep the chat in the dialog list (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleDeleteChat),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_create_group",
 			mcp.WithDescription("Create a new group chat"),
@@ -123,6 +200,27 @@ func RegisterChatTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleToggleDialogPin),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_pinned_dialogs",
+			mcp.WithDescription("List pinned chats in their pinned order"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("folder_id", mcp.Description("Folder: 0 for the main chat list, 1 for the archive (default 0)")),
+		),
+		mcp.NewTypedToolHandler(handleGetPinnedDialogs),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_reorder_pinned_dialogs",
+			mcp.WithDescription("Reorder pinned chats. The given chats move to the top in the given order; other pinned chats keep their relative order below them"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peers", mcp.Required(), mcp.Description("Comma-separated pinned chat IDs or @usernames, in the desired order")),
+			mcp.WithNumber("folder_id", mcp.Description("Folder: 0 for the main chat list, 1 for the archive (default 0)")),
+		),
+		mcp.NewTypedToolHandler(handleReorderPinnedDialogs),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_mark_dialog_unread",
 			mcp.WithDescription("Mark or unmark a dialog/chat as unread"),
@@ -133,6 +231,47 @@ func RegisterChatTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleMarkDialogUnread),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_unread_marks",
+			mcp.WithDescription("List dialogs manually marked as unread separately from dialogs with actual unread messages"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("limit", mcp.Description("Number of recent dialogs to scan for unread messages (default 50, max 100)")),
+		),
+		mcp.NewTypedToolHandler(handleGetUnreadMarks),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_online_count",
+			mcp.WithDescription("Get the number of currently online members of a group or supergroup without listing them"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the group or supergroup")),
+		),
+		mcp.NewTypedToolHandler(handleGetOnlineCount),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_channel_recommendations",
+			mcp.WithDescription("Get channels similar to a given channel, or channels recommended for you when no channel is given"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Description("Channel ID or @username to find similar channels for (optional)")),
+		),
+		mcp.NewTypedToolHandler(handleGetChannelRecommendations),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_default_send_as",
+			mcp.WithDescription("Set the identity used by default when posting in a group, e.g. always post as a channel you administer"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Supergroup or channel discussion group ID or @username")),
+			mcp.WithString("send_as", mcp.Required(), mcp.Description("Channel ID or @username to post as, or \"me\" to post as yourself")),
+		),
+		mcp.NewTypedToolHandler(handleSetDefaultSendAs),
+	)
 }
 
 func handleListChats(_ context.Context, _ mcp.CallToolRequest, input listChatsInput) (*mcp.CallToolResult, error) {
@@ -375,23 +514,59 @@ func handleSearchChats(_ context.Context, _ mcp.CallToolRequest, input searchCha
 		b.WriteString("\nNo results found.")
 	}
 
+	if input.IncludeSimilar {
+		for _, c := range found.Chats {
+			ch, ok := c.(*tg.Channel)
+			if !ok {
+				continue
+			}
+			writeSimilarChannels(tgCtx, &b, ch)
+			break
+		}
+	}
+
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+// writeSimilarChannels appends up to 10 channels Telegram recommends as similar to ch.
+func writeSimilarChannels(ctx context.Context, b *strings.Builder, ch *tg.Channel) {
+	req := &tg.ChannelsGetChannelRecommendationsRequest{}
+	req.SetChannel(&tg.InputChannel{ChannelID: ch.ID, AccessHash: ch.AccessHash})
+
+	result, err := services.API().ChannelsGetChannelRecommendations(ctx, req)
+	if err != nil {
+		fmt.Fprintf(b, "\n\nFailed to get channels similar to %s: %v\n", ch.Title, err)
+		return
+	}
+
+	chats := result.GetChats()
+	if len(chats) == 0 {
+		fmt.Fprintf(b, "\n\nNo channels similar to %s found.\n", ch.Title)
+		return
+	}
+	services.StorePeers(ctx, chats, nil)
+
+	if len(chats) > 10 {
+		chats = chats[:10]
+	}
+	fmt.Fprintf(b, "\n\nSimilar to %s (%d):\n", ch.Title, len(chats))
+	for _, c := range chats {
+		b.WriteString("\n")
+		formatChat(b, c)
+	}
+}
+
 func handleJoinChat(_ context.Context, _ mcp.CallToolRequest, input joinChatInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
 	peerStr := input.Peer
 
 	// Handle invite links
-	var inviteHash string
-	if strings.HasPrefix(peerStr, "https://t.me/+") {
-		inviteHash = strings.TrimPrefix(peerStr, "https://t.me/+")
-	} else if strings.HasPrefix(peerStr, "https://t.me/joinchat/") {
-		inviteHash = strings.TrimPrefix(peerStr, "https://t.me/joinchat/")
-	}
-	if inviteHash != "" {
+	if inviteHash := inviteHashFromLink(peerStr); inviteHash != "" {
 		_, err := services.API().MessagesImportChatInvite(tgCtx, inviteHash)
+		if tg.IsInviteRequestSent(err) {
+			return mcp.NewToolResultText("Join request sent, pending admin approval."), nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to join via invite link: %v", err)), nil
 		}
@@ -420,6 +595,111 @@ func handleJoinChat(_ context.Context, _ mcp.CallToolRequest, input joinChatInpu
 	return mcp.NewToolResultText("Joined channel successfully."), nil
 }
 
+// inviteHashFromLink extracts the hash from a t.me invite link, or returns an empty string.
+func inviteHashFromLink(link string) string {
+	if strings.HasPrefix(link, "https://t.me/+") {
+		return strings.TrimPrefix(link, "https://t.me/+")
+	}
+	if strings.HasPrefix(link, "https://t.me/joinchat/") {
+		return strings.TrimPrefix(link, "https://t.me/joinchat/")
+	}
+	return ""
+}
+
+func handleCheckInviteStatus(_ context.Context, _ mcp.CallToolRequest, input checkInviteStatusInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	inviteHash := inviteHashFromLink(input.Link)
+	if inviteHash == "" {
+		return mcp.NewToolResultError("link is not a t.me invite link"), nil
+	}
+
+	result, err := services.API().MessagesCheckChatInvite(tgCtx, inviteHash)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to check invite link: %v", err)), nil
+	}
+
+	var b strings.Builder
+	switch invite := result.(type) {
+	case *tg.ChatInviteAlready:
+		services.StorePeers(tgCtx, []tg.ChatClass{invite.Chat}, nil)
+		b.WriteString("Status: joined\n\n")
+		formatChat(&b, invite.Chat)
+	case *tg.ChatInvitePeek:
+		services.StorePeers(tgCtx, []tg.ChatClass{invite.Chat}, nil)
+		fmt.Fprintf(&b, "Status: not joined (preview access until %s)\n\n", time.Unix(int64(invite.Expires), 0).UTC().Format("2006-01-02 15:04:05"))
+		formatChat(&b, invite.Chat)
+	case *tg.ChatInvite:
+		if invite.RequestNeeded {
+			// Telegram doesn't report whether a request was already sent
+			b.WriteString("Status: not joined, admin approval required (pending if you already sent a join request)\n")
+		} else {
+			b.WriteString("Status: not joined, can join directly\n")
+		}
+		fmt.Fprintf(&b, "Title: %s\nMembers: %d\n", invite.Title, invite.ParticipantsCount)
+		if invite.About != "" {
+			fmt.Fprintf(&b, "Description: %s\n", invite.About)
+		}
+	default:
+		return mcp.NewToolResultError("unexpected response type"), nil
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleResolveInviteLink(_ context.Context, _ mcp.CallToolRequest, input resolveInviteLinkInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	inviteHash := inviteHashFromLink(input.Link)
+	if inviteHash == "" {
+		return mcp.NewToolResultError("link is not a t.me invite link"), nil
+	}
+
+	result, err := services.API().MessagesCheckChatInvite(tgCtx, inviteHash)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to check invite link: %v", err)), nil
+	}
+
+	var chat tg.ChatClass
+	switch invite := result.(type) {
+	case *tg.ChatInviteAlready:
+		chat = invite.Chat
+	case *tg.ChatInvitePeek:
+		chat = invite.Chat
+	case *tg.ChatInvite:
+		return mcp.NewToolResultError(fmt.Sprintf("not a member of %q yet; join it with telegram_join_chat first", invite.Title)), nil
+	default:
+		return mcp.NewToolResultError("unexpected response type"), nil
+	}
+
+	// Storing the chat lets ID-based tools resolve it with its access hash
+	services.StorePeers(tgCtx, []tg.ChatClass{chat}, nil)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Peer: %d\n\n", chat.GetID())
+	formatChat(&b, chat)
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// deleteHistoryFully repeats req until Telegram reports nothing left to delete,
+// since each MessagesDeleteHistory call only removes one batch of messages.
+func deleteHistoryFully(ctx context.Context, req *tg.MessagesDeleteHistoryRequest) error {
+	for {
+		var affected *tg.MessagesAffectedHistory
+		err := services.WithFloodRetry(ctx, func(ctx context.Context) error {
+			var err error
+			affected, err = services.API().MessagesDeleteHistory(ctx, req)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if affected.Offset == 0 {
+			return nil
+		}
+	}
+}
+
 func handleLeaveChat(_ context.Context, _ mcp.CallToolRequest, input leaveChatInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -447,13 +727,68 @@ func handleLeaveChat(_ context.Context, _ mcp.CallToolRequest, input leaveChatIn
 			return mcp.NewToolResultError(fmt.Sprintf("failed to leave chat: %v", err)), nil
 		}
 
+		// Basic groups stay in the dialog list after leaving; channels are removed by Telegram.
+		if input.Delete {
+			err = deleteHistoryFully(tgCtx, &tg.MessagesDeleteHistoryRequest{Peer: peer})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("left chat but failed to delete history: %v", err)), nil
+			}
+		}
+
 	default:
 		return mcp.NewToolResultError("cannot leave this type of peer"), nil
 	}
 
+	if input.Delete {
+		return mcp.NewToolResultText("Left chat and deleted it successfully."), nil
+	}
 	return mcp.NewToolResultText("Left chat successfully."), nil
 }
 
+func handleDeleteChat(_ context.Context, _ mcp.CallToolRequest, input deleteChatInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	switch input.Mode {
+	case "group":
+		chat, ok := peer.(*tg.InputPeerChat)
+		if !ok {
+			return mcp.NewToolResultError("mode group only works for basic groups"), nil
+		}
+
+		_, err = services.API().MessagesDeleteChat(tgCtx, chat.ChatID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to delete group: %v", err)), nil
+		}
+		return mcp.NewToolResultText("Group deleted for all members successfully."), nil
+
+	case "for_me":
+		if _, ok := peer.(*tg.InputPeerChannel); ok {
+			return mcp.NewToolResultError("channels and supergroups can't be deleted for yourself only, use telegram_leave_chat instead"), nil
+		}
+
+		err = deleteHistoryFully(tgCtx, &tg.MessagesDeleteHistoryRequest{
+			Peer:      peer,
+			JustClear: input.JustClear,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to delete chat: %v", err)), nil
+		}
+
+		if input.JustClear {
+			return mcp.NewToolResultText("Chat history cleared for you successfully."), nil
+		}
+		return mcp.NewToolResultText("Chat deleted for you successfully."), nil
+
+	default:
+		return mcp.NewToolResultError("mode must be group or for_me"), nil
+	}
+}
+
 func handleCreateGroup(_ context.Context, _ mcp.CallToolRequest, input createGroupInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -525,6 +860,111 @@ func handleToggleDialogPin(_ context.Context, _ mcp.CallToolRequest, input toggl
 	return mcp.NewToolResultText(fmt.Sprintf("Dialog %s successfully.", action)), nil
 }
 
+func handleGetPinnedDialogs(_ context.Context, _ mcp.CallToolRequest, input getPinnedDialogsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	result, err := services.API().MessagesGetPinnedDialogs(tgCtx, input.FolderID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get pinned dialogs: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	var b strings.Builder
+	n := 0
+	for _, dc := range result.Dialogs {
+		d, ok := dc.(*tg.Dialog)
+		if !ok {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%d. %s (ID: %d)", n, senderLabel(tgCtx, d.Peer), peerToID(d.Peer))
+		if d.UnreadCount > 0 {
+			fmt.Fprintf(&b, " [%d unread]", d.UnreadCount)
+		}
+		b.WriteString("\n")
+	}
+
+	if n == 0 {
+		return mcp.NewToolResultText("No pinned dialogs."), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Pinned dialogs (%d):\n", n) + b.String()), nil
+}
+
+func handleReorderPinnedDialogs(_ context.Context, _ mcp.CallToolRequest, input reorderPinnedDialogsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peers, err := resolvePeerList(tgCtx, input.Peers)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peers: %v", err)), nil
+	}
+	if len(peers) == 0 {
+		return mcp.NewToolResultError("peers is required"), nil
+	}
+
+	current, err := services.API().MessagesGetPinnedDialogs(tgCtx, input.FolderID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get pinned dialogs: %v", err)), nil
+	}
+
+	// Pinned chats that were never fetched before can only be resolved from this response
+	services.StorePeers(tgCtx, current.Chats, current.Users)
+
+	pinned := make(map[int64]bool)
+	for _, dc := range current.Dialogs {
+		if d, ok := dc.(*tg.Dialog); ok {
+			pinned[peerToID(d.Peer)] = true
+		}
+	}
+
+	order := make([]tg.InputDialogPeerClass, 0, len(current.Dialogs))
+	listed := make(map[int64]bool)
+	for _, peer := range peers {
+		id := peerToID(inputPeerToPeer(peer))
+		if !pinned[id] {
+			return mcp.NewToolResultError(fmt.Sprintf("%s is not a pinned dialog", inputPeerLabel(tgCtx, peer))), nil
+		}
+		if listed[id] {
+			continue
+		}
+		listed[id] = true
+		order = append(order, &tg.InputDialogPeer{Peer: peer})
+	}
+
+	// Keep the remaining pinned dialogs below, in their current order
+	for _, dc := range current.Dialogs {
+		var d *tg.Dialog
+		switch dlg := dc.(type) {
+		case *tg.Dialog:
+			d = dlg
+		case *tg.DialogFolder:
+			// The pinned archive is a folder entry, not a peer; leaving it out would unpin it
+			order = append(order, &tg.InputDialogPeerFolder{FolderID: dlg.Folder.ID})
+			continue
+		default:
+			continue
+		}
+		if listed[peerToID(d.Peer)] {
+			continue
+		}
+		peer, err := services.GetInputPeerByID(tgCtx, peerToID(d.Peer))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve pinned dialog %d: %v", peerToID(d.Peer), err)), nil
+		}
+		order = append(order, &tg.InputDialogPeer{Peer: peer})
+	}
+
+	_, err = services.API().MessagesReorderPinnedDialogs(tgCtx, &tg.MessagesReorderPinnedDialogsRequest{
+		FolderID: input.FolderID,
+		Order:    order,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to reorder pinned dialogs: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Pinned dialogs reordered (%d).", len(order))), nil
+}
+
 func handleMarkDialogUnread(_ context.Context, _ mcp.CallToolRequest, input markDialogUnreadInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -552,3 +992,184 @@ func handleMarkDialogUnread(_ context.Context, _ mcp.CallToolRequest, input mark
 	}
 	return mcp.NewToolResultText(fmt.Sprintf("Dialog %s successfully.", action)), nil
 }
+
+func handleGetUnreadMarks(_ context.Context, _ mcp.CallToolRequest, input getUnreadMarksInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetDialogs(tgCtx, &tg.MessagesGetDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get dialogs: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok {
+		return mcp.NewToolResultError("no dialogs returned"), nil
+	}
+
+	services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+	// The marks list covers all dialogs, not just the scanned ones
+	marks, err := services.API().MessagesGetDialogUnreadMarks(tgCtx, &tg.MessagesGetDialogUnreadMarksRequest{})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get unread marks: %v", err)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Marked as unread (%d):\n", len(marks))
+	for _, m := range marks {
+		if dp, ok := m.(*tg.DialogPeer); ok {
+			fmt.Fprintf(&b, "  %s (ID: %d)\n", senderLabel(tgCtx, dp.Peer), peerToID(dp.Peer))
+		}
+	}
+	if len(marks) == 0 {
+		b.WriteString("  (none)\n")
+	}
+
+	var unread strings.Builder
+	unreadCount := 0
+	for _, dc := range modified.GetDialogs() {
+		d, ok := dc.(*tg.Dialog)
+		if !ok || d.UnreadCount == 0 {
+			continue
+		}
+		unreadCount++
+		fmt.Fprintf(&unread, "  %s (ID: %d): %d unread", senderLabel(tgCtx, d.Peer), peerToID(d.Peer), d.UnreadCount)
+		if d.UnreadMark {
+			unread.WriteString(" [also marked]")
+		}
+		unread.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\nWith unread messages (%d):\n", unreadCount)
+	if unreadCount == 0 {
+		b.WriteString("  (none)\n")
+	}
+	b.WriteString(unread.String())
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetOnlineCount(_ context.Context, _ mcp.CallToolRequest, input getOnlineCountInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	switch p := peer.(type) {
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(tgCtx, &tg.InputChannel{
+			ChannelID:  p.ChannelID,
+			AccessHash: p.AccessHash,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get channel info: %v", err)), nil
+		}
+
+		full, ok := fullResult.FullChat.(*tg.ChannelFull)
+		if !ok {
+			return mcp.NewToolResultError("unexpected response type"), nil
+		}
+
+		online, ok := full.GetOnlineCount()
+		if !ok {
+			return mcp.NewToolResultText("Online count is not available for this chat."), nil
+		}
+		if members, ok := full.GetParticipantsCount(); ok {
+			return mcp.NewToolResultText(fmt.Sprintf("Online: %d of %d members", online, members)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Online: %d", online)), nil
+
+	case *tg.InputPeerChat:
+		onlines, err := services.API().MessagesGetOnlines(tgCtx, peer)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get online count: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Online: %d", onlines.Onlines)), nil
+
+	default:
+		return mcp.NewToolResultError("peer is not a group or supergroup"), nil
+	}
+}
+
+func handleGetChannelRecommendations(_ context.Context, _ mcp.CallToolRequest, input getChannelRecommendationsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	req := &tg.ChannelsGetChannelRecommendationsRequest{}
+	if input.Peer != "" {
+		peer, err := services.ResolvePeer(tgCtx, input.Peer)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+		}
+		inputChannel, ok := toInputChannel(peer)
+		if !ok {
+			return mcp.NewToolResultError("peer is not a channel"), nil
+		}
+		req.SetChannel(inputChannel)
+	}
+
+	result, err := services.API().ChannelsGetChannelRecommendations(tgCtx, req)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get channel recommendations: %v", err)), nil
+	}
+
+	chats := result.GetChats()
+	if len(chats) == 0 {
+		return mcp.NewToolResultText("No recommended channels found."), nil
+	}
+
+	services.StorePeers(tgCtx, chats, nil)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Recommended channels (%d):\n", len(chats))
+	for _, c := range chats {
+		b.WriteString("\n")
+		formatChat(&b, c)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleSetDefaultSendAs(_ context.Context, _ mcp.CallToolRequest, input setDefaultSendAsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+	if _, ok := peer.(*tg.InputPeerChannel); !ok {
+		return mcp.NewToolResultError("peer is not a supergroup or channel"), nil
+	}
+
+	var sendAs tg.InputPeerClass = &tg.InputPeerSelf{}
+	sendAsLabel := "yourself"
+	if !strings.EqualFold(input.SendAs, "me") {
+		sendAs, err = services.ResolvePeer(tgCtx, input.SendAs)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve send_as: %v", err)), nil
+		}
+		sendAsLabel = inputPeerLabel(tgCtx, sendAs)
+	}
+
+	_, err = services.API().MessagesSaveDefaultSendAs(tgCtx, &tg.MessagesSaveDefaultSendAsRequest{
+		Peer:   peer,
+		SendAs: sendAs,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set default send-as: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Messages in %s will now be sent as %s by default.", inputPeerLabel(tgCtx, peer), sendAsLabel)), nil
+}
@@ -2,10 +2,19 @@ package tools
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
+	"github.com/gotd/contrib/storage"
 	"github.com/gotd/td/tg"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,8 +24,18 @@ import (
 // Get Unread
 
 type getUnreadInput struct {
-	Limit           int `json:"limit"`
-	MessagesPerChat int `json:"messages_per_chat"`
+	Limit           int  `json:"limit"`
+	MessagesPerChat int  `json:"messages_per_chat"`
+	Since           int  `json:"since"`
+	Until           int  `json:"until"`
+	SkipMuted       bool `json:"skip_muted"`
+}
+
+// Dialog Overview
+
+type dialogOverviewInput struct {
+	Limit      int  `json:"limit"`
+	UnreadOnly bool `json:"unread_only"`
 }
 
 // Chat Context
@@ -24,6 +43,7 @@ type getUnreadInput struct {
 type chatContextInput struct {
 	Peer         string `json:"peer" jsonschema:"required"`
 	MessageLimit int    `json:"message_limit"`
+	Sections     string `json:"sections"`
 }
 
 // Forward Bulk
@@ -34,12 +54,83 @@ type forwardBulkInput struct {
 	ToPeers    string `json:"to_peers" jsonschema:"required"`
 }
 
+// Batch Send
+
+type batchSendInput struct {
+	Peers   string `json:"peers" jsonschema:"required"`
+	Message string `json:"message" jsonschema:"required"`
+}
+
+// Forward With Edit
+
+type forwardWithEditInput struct {
+	FromPeer  string `json:"from_peer" jsonschema:"required"`
+	MessageID int    `json:"message_id" jsonschema:"required"`
+	ToPeer    string `json:"to_peer" jsonschema:"required"`
+	Text      string `json:"text" jsonschema:"required"`
+}
+
 // Export Messages
 
 type exportMessagesInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	Limit     int    `json:"limit"`
+	Since     int    `json:"since"`
+	Format    string `json:"format"`
+	OutputDir string `json:"output_dir"`
+}
+
+// Get Scheduled
+
+type getScheduledInput struct {
+	Limit int `json:"limit"`
+}
+
+// My Stats
+
+type getMyStatsInput struct{}
+
+// Whois
+
+type whoisInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+// Chat Timeline
+
+type chatTimelineInput struct {
+	Peer   string `json:"peer" jsonschema:"required"`
+	Limit  int    `json:"limit"`
+	Bucket string `json:"bucket"`
+}
+
+// Unanswered Questions
+
+type findUnansweredInput struct {
+	Peer  string `json:"peer" jsonschema:"required"`
+	Limit int    `json:"limit"`
+}
+
+// New Members
+
+type getNewMembersInput struct {
+	Peer  string `json:"peer" jsonschema:"required"`
+	Since int    `json:"since" jsonschema:"required"`
+	Limit int    `json:"limit"`
+}
+
+// Spam Candidates
+
+type detectSpamInput struct {
 	Peer  string `json:"peer" jsonschema:"required"`
 	Limit int    `json:"limit"`
-	Since int    `json:"since"`
+}
+
+// Report Spam And Block
+
+type reportSpamAndBlockInput struct {
+	Peer       string `json:"peer" jsonschema:"required"`
+	DeleteChat bool   `json:"delete_chat"`
 }
 
 // Search Cross Chat
@@ -58,17 +149,32 @@ func RegisterCompoundTools(s *server.MCPServer) {
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithNumber("limit", mcp.Description("Max number of dialogs to scan (default 20)")),
 			mcp.WithNumber("messages_per_chat", mcp.Description("Number of recent messages per unread chat (default 3)")),
+			mcp.WithNumber("since", mcp.Description("Only include dialogs whose latest message is at or after this unix timestamp (optional)")),
+			mcp.WithNumber("until", mcp.Description("Only include dialogs whose latest message is at or before this unix timestamp (optional)")),
+			mcp.WithBoolean("skip_muted", mcp.Description("Exclude muted chats (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleGetUnread),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_dialog_overview",
+			mcp.WithDescription("Triage view of dialogs: unread count, last message snippet, and saved draft per chat in a single call"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("limit", mcp.Description("Max number of dialogs to scan (default 20)")),
+			mcp.WithBoolean("unread_only", mcp.Description("Only include dialogs with unread messages or a draft (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleDialogOverview),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_chat_context",
-			mcp.WithDescription("Get complete context for a chat: info, recent messages, pinned messages, and participants"),
+			mcp.WithDescription("Get complete context for a chat: info, recent messages, pinned messages, participants, and pending join requests"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("message_limit", mcp.Description("Number of recent messages to retrieve (default 20)")),
+			mcp.WithString("sections", mcp.Description("Comma-separated sections to fetch: info, participants, messages, pinned, requests (default all)")),
 		),
 		mcp.NewTypedToolHandler(handleChatContext),
 	)
@@ -85,6 +191,30 @@ func RegisterCompoundTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleForwardBulk),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_batch_send",
+			mcp.WithDescription("Send the same composed message to multiple chats in a single call, reporting success per chat"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peers", mcp.Required(), mcp.Description("Comma-separated destination chat IDs or @usernames")),
+			mcp.WithString("message", mcp.Required(), mcp.Description("Message text to send. Supports per-chat placeholders {chat_title}, {first_name}, {last_name} and {username}")),
+		),
+		mcp.NewTypedToolHandler(handleBatchSend),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_forward_with_edit",
+			mcp.WithDescription("Forward a message without the author header, then replace the copy's text or caption in the destination"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("from_peer", mcp.Required(), mcp.Description("Source chat ID or @username")),
+			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message to forward")),
+			mcp.WithString("to_peer", mcp.Required(), mcp.Description("Destination chat ID or @username")),
+			mcp.WithString("text", mcp.Required(), mcp.Description("New text or caption for the forwarded copy")),
+		),
+		mcp.NewTypedToolHandler(handleForwardWithEdit),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_export_messages",
 			mcp.WithDescription("Export message history with auto-pagination, retrieving more messages than single-call limit"),
@@ -93,10 +223,98 @@ func RegisterCompoundTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("limit", mcp.Description("Total number of messages to export (default 100, max 500)")),
 			mcp.WithNumber("since", mcp.Description("Unix timestamp to filter messages after this date (optional)")),
+			mcp.WithString("format", mcp.Description("Output format: text or csv (default text). csv writes a file and returns its path")),
+			mcp.WithString("output_dir", mcp.Description("Directory to write the csv file to (default ./downloads)")),
 		),
 		mcp.NewTypedToolHandler(handleExportMessages),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_scheduled",
+			mcp.WithDescription("List pending scheduled messages across all recent chats, ordered by send time"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("limit", mcp.Description("Number of recent dialogs to scan (default 50, max 100)")),
+		),
+		mcp.NewTypedToolHandler(handleGetScheduled),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_my_stats",
+			mcp.WithDescription("Get an account overview in one call: dialogs, unread total, contacts, folders and blocked users"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetMyStats),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_whois",
+			mcp.WithDescription("One-shot profile lookup for any user, group or channel: info, presence, bio, flags, stories, common chats, and contact/blocked state"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("User, group or channel ID, @username, or phone number with a leading + (e.g. +84...)")),
+		),
+		mcp.NewTypedToolHandler(handleWhois),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_chat_timeline",
+			mcp.WithDescription("Activity heatmap for a chat: message counts per hour of day, weekday or date over recent history, with the top posters in each bucket"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("limit", mcp.Description("Number of recent messages to analyze (default 500, max 2000)")),
+			mcp.WithString("bucket", mcp.Description("Bucket size: hour (hour of day), weekday, or day (calendar date). Default hour. Times are UTC")),
+		),
+		mcp.NewTypedToolHandler(handleGetChatTimeline),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_find_unanswered_questions",
+			mcp.WithDescription("Find recent questions in a chat that nobody has replied to. A message counts as a question if it ends with '?' or starts with a question word, and as answered if any later message replies to it"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("limit", mcp.Description("Number of recent messages to scan (default 200, max 1000)")),
+		),
+		mcp.NewTypedToolHandler(handleFindUnansweredQuestions),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_new_members",
+			mcp.WithDescription("List members who joined a supergroup since a given time, from the admin log, so they can be welcomed. Requires admin rights"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Supergroup ID or @username")),
+			mcp.WithNumber("since", mcp.Required(), mcp.Description("Unix timestamp; only joins at or after this time are listed")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of members to return (default 50, max 500)")),
+		),
+		mcp.NewTypedToolHandler(handleGetNewMembers),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_detect_spam_candidates",
+			mcp.WithDescription("Scan recent messages in a group and flag likely spam for review: many links, all caps, identical text posted by several users, or senders marked scam/fake by Telegram. Nothing is deleted"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Group ID or @username")),
+			mcp.WithNumber("limit", mcp.Description("Number of recent messages to scan (default 200, max 1000)")),
+		),
+		mcp.NewTypedToolHandler(handleDetectSpamCandidates),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_report_spam_and_block",
+			mcp.WithDescription("Deal with a spam DM in one call: report the chat as spam, block the sender, and optionally delete the chat"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("User ID or @username of the sender")),
+			mcp.WithBoolean("delete_chat", mcp.Description("Also delete the chat from your dialog list (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleReportSpamAndBlock),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_search_cross_chat",
 			mcp.WithDescription("Search for a query across multiple specific chats in a single call"),
@@ -166,6 +384,14 @@ func handleGetUnread(_ context.Context, _ mcp.CallToolRequest, input getUnreadIn
 		}
 	}
 
+	// Date of each dialog's top message, keyed by peer ID
+	topDates := make(map[int64]int)
+	for _, mc := range modified.GetMessages() {
+		if msg, ok := mc.AsNotEmpty(); ok {
+			topDates[peerToID(msg.GetPeerID())] = msg.GetDate()
+		}
+	}
+
 	var sb strings.Builder
 	unreadCount := 0
 
@@ -174,6 +400,18 @@ func handleGetUnread(_ context.Context, _ mcp.CallToolRequest, input getUnreadIn
 		if !ok || d.UnreadCount <= 0 {
 			continue
 		}
+		if input.SkipMuted && isMuted(d.NotifySettings) {
+			continue
+		}
+		if input.Since > 0 || input.Until > 0 {
+			date := topDates[peerToID(d.Peer)]
+			if input.Since > 0 && date < input.Since {
+				continue
+			}
+			if input.Until > 0 && date > input.Until {
+				continue
+			}
+		}
 		unreadCount++
 
 		// Identify the dialog
@@ -277,6 +515,97 @@ func handleGetUnread(_ context.Context, _ mcp.CallToolRequest, input getUnreadIn
 	return mcp.NewToolResultText(header + sb.String()), nil
 }
 
+func handleDialogOverview(_ context.Context, _ mcp.CallToolRequest, input dialogOverviewInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetDialogs(tgCtx, &tg.MessagesGetDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get dialogs: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok {
+		return mcp.NewToolResultError("no dialogs returned"), nil
+	}
+
+	services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+	// Top message of each dialog, keyed by peer ID
+	topMessages := make(map[int64]*tg.Message)
+	for _, mc := range modified.GetMessages() {
+		if msg, ok := mc.(*tg.Message); ok {
+			topMessages[peerToID(msg.PeerID)] = msg
+		}
+	}
+
+	var sb strings.Builder
+	shown := 0
+
+	for _, dc := range modified.GetDialogs() {
+		d, ok := dc.(*tg.Dialog)
+		if !ok {
+			continue
+		}
+
+		var draftText string
+		if draft, ok := d.GetDraft(); ok {
+			if dm, ok := draft.AsNotEmpty(); ok {
+				draftText = dm.Message
+			}
+		}
+
+		if input.UnreadOnly && d.UnreadCount == 0 && draftText == "" {
+			continue
+		}
+		shown++
+
+		peerID := peerToID(d.Peer)
+		fmt.Fprintf(&sb, "\n%s (ID: %d)", senderLabel(tgCtx, d.Peer), peerID)
+		if d.UnreadCount > 0 {
+			fmt.Fprintf(&sb, " — %d unread", d.UnreadCount)
+		}
+		sb.WriteString("\n")
+
+		if msg, ok := topMessages[peerID]; ok {
+			t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+			fmt.Fprintf(&sb, "  Last: [%d] %s (%s): %s\n", msg.ID, senderLabel(tgCtx, messageSender(msg)), t, truncateText(msg.Message, 200))
+		}
+		if draftText != "" {
+			fmt.Fprintf(&sb, "  Draft: %s\n", draftText)
+		}
+	}
+
+	if shown == 0 {
+		return mcp.NewToolResultText("No matching dialogs."), nil
+	}
+
+	header := fmt.Sprintf("Dialogs: %d\n", shown)
+	return mcp.NewToolResultText(header + sb.String()), nil
+}
+
+// truncateText shortens s to at most n runes, appending an ellipsis when cut.
+func truncateText(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "..."
+}
+
+// chatContextSections lists the sections of telegram_chat_context in output order.
+var chatContextSections = []string{"info", "participants", "messages", "pinned", "requests"}
+
 func handleChatContext(_ context.Context, _ mcp.CallToolRequest, input chatContextInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -285,6 +614,17 @@ func handleChatContext(_ context.Context, _ mcp.CallToolRequest, input chatConte
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
+	switch peer.(type) {
+	case *tg.InputPeerChannel, *tg.InputPeerChat, *tg.InputPeerUser:
+	default:
+		return mcp.NewToolResultError("unsupported peer type"), nil
+	}
+
+	sections, err := parseChatContextSections(input.Sections)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	msgLimit := input.MessageLimit
 	if msgLimit <= 0 {
 		msgLimit = 20
@@ -293,190 +633,301 @@ func handleChatContext(_ context.Context, _ mcp.CallToolRequest, input chatConte
 		msgLimit = 100
 	}
 
+	// Sections are fetched concurrently into their own buffers and joined in chatContextSections order
+	outputs := make([]strings.Builder, len(chatContextSections))
+	var wg sync.WaitGroup
+	run := func(name string, fetch func(sb *strings.Builder)) {
+		i := slices.Index(chatContextSections, name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetch(&outputs[i])
+		}()
+	}
+
+	if sections["info"] {
+		run("info", func(sb *strings.Builder) {
+			sb.WriteString("== Chat Info ==\n")
+			writeChatContextInfo(tgCtx, sb, peer)
+		})
+	}
+
+	// Participants are only listed for supergroups and channels
+	if channel, ok := toInputChannel(peer); ok && sections["participants"] {
+		run("participants", func(sb *strings.Builder) {
+			sb.WriteString("\n== Participants (up to 50) ==\n")
+			writeChatContextParticipants(tgCtx, sb, channel)
+		})
+	}
+
+	if sections["messages"] {
+		run("messages", func(sb *strings.Builder) {
+			sb.WriteString("\n== Recent Messages ==\n")
+			histResult, err := services.API().MessagesGetHistory(tgCtx, &tg.MessagesGetHistoryRequest{
+				Peer:  peer,
+				Limit: msgLimit,
+			})
+			if err != nil {
+				fmt.Fprintf(sb, "Failed to get history: %v\n", err)
+				return
+			}
+			msgs := extractMessages(tgCtx, histResult)
+			sb.WriteString(formatMessages(tgCtx, msgs))
+		})
+	}
+
+	if sections["pinned"] {
+		run("pinned", func(sb *strings.Builder) {
+			sb.WriteString("\n== Pinned Messages ==\n")
+			pinnedResult, err := services.API().MessagesSearch(tgCtx, &tg.MessagesSearchRequest{
+				Peer:   peer,
+				Q:      "",
+				Filter: &tg.InputMessagesFilterPinned{},
+				Limit:  20,
+			})
+			if err != nil {
+				fmt.Fprintf(sb, "Failed to get pinned messages: %v\n", err)
+				return
+			}
+			pinned := extractMessages(tgCtx, pinnedResult)
+			if len(pinned) == 0 {
+				sb.WriteString("No pinned messages.\n")
+			} else {
+				sb.WriteString(formatMessages(tgCtx, pinned))
+			}
+		})
+	}
+
+	// Pending join requests (groups and channels only)
+	if _, isUser := peer.(*tg.InputPeerUser); !isUser && sections["requests"] {
+		run("requests", func(sb *strings.Builder) {
+			sb.WriteString("\n== Pending Join Requests ==\n")
+			writePendingJoinRequests(tgCtx, sb, peer)
+		})
+	}
+
+	wg.Wait()
+
 	var sb strings.Builder
+	for i := range outputs {
+		sb.WriteString(outputs[i].String())
+	}
+
+	return mcp.NewToolResultText(strings.TrimPrefix(sb.String(), "\n")), nil
+}
+
+// parseChatContextSections parses a comma-separated section list, defaulting to all sections.
+func parseChatContextSections(s string) (map[string]bool, error) {
+	sections := make(map[string]bool, len(chatContextSections))
+	if strings.TrimSpace(s) == "" {
+		for _, name := range chatContextSections {
+			sections[name] = true
+		}
+		return sections, nil
+	}
 
-	// Section 1: Chat info
-	sb.WriteString("== Chat Info ==\n")
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(chatContextSections, name) {
+			return nil, fmt.Errorf("unknown section %q (valid: %s)", name, strings.Join(chatContextSections, ", "))
+		}
+		sections[name] = true
+	}
+	return sections, nil
+}
 
+func writeChatContextInfo(ctx context.Context, sb *strings.Builder, peer tg.InputPeerClass) {
 	switch p := peer.(type) {
 	case *tg.InputPeerChannel:
-		channel := &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash}
-
-		fullResult, err := services.API().ChannelsGetFullChannel(tgCtx, channel)
+		fullResult, err := services.API().ChannelsGetFullChannel(ctx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
 		if err != nil {
-			fmt.Fprintf(&sb, "Failed to get channel info: %v\n", err)
-		} else {
-			services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
-			for _, c := range fullResult.Chats {
-				if ch, ok := c.(*tg.Channel); ok && ch.ID == p.ChannelID {
-					fmt.Fprintf(&sb, "Title: %s\n", ch.Title)
-					fmt.Fprintf(&sb, "ID: %d\n", ch.ID)
-					if ch.Username != "" {
-						fmt.Fprintf(&sb, "Username: @%s\n", ch.Username)
-					}
-					if ch.Megagroup {
-						sb.WriteString("Type: Supergroup\n")
-					} else if ch.Broadcast {
-						sb.WriteString("Type: Broadcast Channel\n")
-					} else {
-						sb.WriteString("Type: Channel\n")
-					}
-					break
-				}
-			}
-			if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
-				if full.About != "" {
-					fmt.Fprintf(&sb, "Description: %s\n", full.About)
-				}
-				if count, ok := full.GetParticipantsCount(); ok {
-					fmt.Fprintf(&sb, "Members: %d\n", count)
+			fmt.Fprintf(sb, "Failed to get channel info: %v\n", err)
+			return
+		}
+		services.StorePeers(ctx, fullResult.Chats, fullResult.Users)
+		for _, c := range fullResult.Chats {
+			if ch, ok := c.(*tg.Channel); ok && ch.ID == p.ChannelID {
+				fmt.Fprintf(sb, "Title: %s\n", ch.Title)
+				fmt.Fprintf(sb, "ID: %d\n", ch.ID)
+				if ch.Username != "" {
+					fmt.Fprintf(sb, "Username: @%s\n", ch.Username)
 				}
-				if count, ok := full.GetAdminsCount(); ok {
-					fmt.Fprintf(&sb, "Admins: %d\n", count)
+				if ch.Megagroup {
+					sb.WriteString("Type: Supergroup\n")
+				} else if ch.Broadcast {
+					sb.WriteString("Type: Broadcast Channel\n")
+				} else {
+					sb.WriteString("Type: Channel\n")
 				}
+				break
 			}
 		}
-
-		// Participants (for supergroups)
-		sb.WriteString("\n== Participants (up to 50) ==\n")
-		participants, err := services.API().ChannelsGetParticipants(tgCtx, &tg.ChannelsGetParticipantsRequest{
-			Channel: channel,
-			Filter:  &tg.ChannelParticipantsRecent{},
-			Limit:   50,
-		})
-		if err != nil {
-			fmt.Fprintf(&sb, "Failed to get participants: %v\n", err)
-		} else if cp, ok := participants.(*tg.ChannelsChannelParticipants); ok {
-			services.StorePeers(tgCtx, cp.Chats, cp.Users)
-			userMap := make(map[int64]*tg.User)
-			for _, u := range cp.Users {
-				if user, ok := u.(*tg.User); ok {
-					userMap[user.ID] = user
-				}
+		if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
+			if full.About != "" {
+				fmt.Fprintf(sb, "Description: %s\n", full.About)
 			}
-			for _, pp := range cp.Participants {
-				var userID int64
-				switch pt := pp.(type) {
-				case *tg.ChannelParticipant:
-					userID = pt.UserID
-				case *tg.ChannelParticipantSelf:
-					userID = pt.UserID
-				case *tg.ChannelParticipantCreator:
-					userID = pt.UserID
-				case *tg.ChannelParticipantAdmin:
-					userID = pt.UserID
-				case *tg.ChannelParticipantBanned:
-					continue
-				case *tg.ChannelParticipantLeft:
-					continue
-				default:
-					continue
-				}
-				if user, ok := userMap[userID]; ok {
-					name := user.FirstName
-					if user.LastName != "" {
-						name += " " + user.LastName
-					}
-					fmt.Fprintf(&sb, "  %s (ID: %d)", name, user.ID)
-					if user.Username != "" {
-						fmt.Fprintf(&sb, " @%s", user.Username)
-					}
-					sb.WriteString("\n")
-				}
+			if count, ok := full.GetParticipantsCount(); ok {
+				fmt.Fprintf(sb, "Members: %d\n", count)
+			}
+			if count, ok := full.GetAdminsCount(); ok {
+				fmt.Fprintf(sb, "Admins: %d\n", count)
 			}
 		}
 
 	case *tg.InputPeerChat:
-		fullResult, err := services.API().MessagesGetFullChat(tgCtx, p.ChatID)
+		fullResult, err := services.API().MessagesGetFullChat(ctx, p.ChatID)
 		if err != nil {
-			fmt.Fprintf(&sb, "Failed to get chat info: %v\n", err)
-		} else {
-			services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
-			for _, c := range fullResult.Chats {
-				if chat, ok := c.(*tg.Chat); ok && chat.ID == p.ChatID {
-					fmt.Fprintf(&sb, "Title: %s\n", chat.Title)
-					fmt.Fprintf(&sb, "ID: %d\n", chat.ID)
-					sb.WriteString("Type: Group\n")
-					fmt.Fprintf(&sb, "Members: %d\n", chat.ParticipantsCount)
-					break
-				}
+			fmt.Fprintf(sb, "Failed to get chat info: %v\n", err)
+			return
+		}
+		services.StorePeers(ctx, fullResult.Chats, fullResult.Users)
+		for _, c := range fullResult.Chats {
+			if chat, ok := c.(*tg.Chat); ok && chat.ID == p.ChatID {
+				fmt.Fprintf(sb, "Title: %s\n", chat.Title)
+				fmt.Fprintf(sb, "ID: %d\n", chat.ID)
+				sb.WriteString("Type: Group\n")
+				fmt.Fprintf(sb, "Members: %d\n", chat.ParticipantsCount)
+				break
 			}
-			if full, ok := fullResult.FullChat.(*tg.ChatFull); ok {
-				if full.About != "" {
-					fmt.Fprintf(&sb, "Description: %s\n", full.About)
-				}
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChatFull); ok {
+			if full.About != "" {
+				fmt.Fprintf(sb, "Description: %s\n", full.About)
 			}
 		}
 
 	case *tg.InputPeerUser:
-		result, err := services.API().UsersGetFullUser(tgCtx, &tg.InputUser{
+		result, err := services.API().UsersGetFullUser(ctx, &tg.InputUser{
 			UserID:     p.UserID,
 			AccessHash: p.AccessHash,
 		})
 		if err != nil {
-			fmt.Fprintf(&sb, "Failed to get user info: %v\n", err)
-		} else {
-			services.StorePeers(tgCtx, result.Chats, result.Users)
-			for _, u := range result.Users {
-				if user, ok := u.(*tg.User); ok && user.ID == p.UserID {
-					sb.WriteString("Type: User\n")
-					fmt.Fprintf(&sb, "Name: %s", user.FirstName)
-					if user.LastName != "" {
-						fmt.Fprintf(&sb, " %s", user.LastName)
-					}
-					sb.WriteString("\n")
-					fmt.Fprintf(&sb, "ID: %d\n", user.ID)
-					if user.Username != "" {
-						fmt.Fprintf(&sb, "Username: @%s\n", user.Username)
-					}
-					if user.Phone != "" {
-						fmt.Fprintf(&sb, "Phone: +%s\n", user.Phone)
-					}
-					break
+			fmt.Fprintf(sb, "Failed to get user info: %v\n", err)
+			return
+		}
+		services.StorePeers(ctx, result.Chats, result.Users)
+		for _, u := range result.Users {
+			if user, ok := u.(*tg.User); ok && user.ID == p.UserID {
+				sb.WriteString("Type: User\n")
+				fmt.Fprintf(sb, "Name: %s", user.FirstName)
+				if user.LastName != "" {
+					fmt.Fprintf(sb, " %s", user.LastName)
 				}
-			}
-			if result.FullUser.About != "" {
-				fmt.Fprintf(&sb, "Bio: %s\n", result.FullUser.About)
+				sb.WriteString("\n")
+				fmt.Fprintf(sb, "ID: %d\n", user.ID)
+				if user.Username != "" {
+					fmt.Fprintf(sb, "Username: @%s\n", user.Username)
+				}
+				if user.Phone != "" {
+					fmt.Fprintf(sb, "Phone: +%s\n", user.Phone)
+				}
+				break
 			}
 		}
-
-	default:
-		return mcp.NewToolResultError("unsupported peer type"), nil
+		if result.FullUser.About != "" {
+			fmt.Fprintf(sb, "Bio: %s\n", result.FullUser.About)
+		}
 	}
+}
 
-	// Section 2: Recent messages
-	sb.WriteString("\n== Recent Messages ==\n")
-	histResult, err := services.API().MessagesGetHistory(tgCtx, &tg.MessagesGetHistoryRequest{
-		Peer:  peer,
-		Limit: msgLimit,
+func writeChatContextParticipants(ctx context.Context, sb *strings.Builder, channel *tg.InputChannel) {
+	participants, err := services.API().ChannelsGetParticipants(ctx, &tg.ChannelsGetParticipantsRequest{
+		Channel: channel,
+		Filter:  &tg.ChannelParticipantsRecent{},
+		Limit:   50,
 	})
 	if err != nil {
-		fmt.Fprintf(&sb, "Failed to get history: %v\n", err)
-	} else {
-		msgs := extractMessages(tgCtx, histResult)
-		sb.WriteString(formatMessages(msgs))
+		fmt.Fprintf(sb, "Failed to get participants: %v\n", err)
+		return
+	}
+	cp, ok := participants.(*tg.ChannelsChannelParticipants)
+	if !ok {
+		return
+	}
+
+	services.StorePeers(ctx, cp.Chats, cp.Users)
+	userMap := make(map[int64]*tg.User)
+	for _, u := range cp.Users {
+		if user, ok := u.(*tg.User); ok {
+			userMap[user.ID] = user
+		}
+	}
+	for _, pp := range cp.Participants {
+		var userID int64
+		switch pt := pp.(type) {
+		case *tg.ChannelParticipant:
+			userID = pt.UserID
+		case *tg.ChannelParticipantSelf:
+			userID = pt.UserID
+		case *tg.ChannelParticipantCreator:
+			userID = pt.UserID
+		case *tg.ChannelParticipantAdmin:
+			userID = pt.UserID
+		default:
+			continue
+		}
+		if user, ok := userMap[userID]; ok {
+			name := user.FirstName
+			if user.LastName != "" {
+				name += " " + user.LastName
+			}
+			fmt.Fprintf(sb, "  %s (ID: %d)", name, user.ID)
+			if user.Username != "" {
+				fmt.Fprintf(sb, " @%s", user.Username)
+			}
+			sb.WriteString("\n")
+		}
 	}
+}
 
-	// Section 3: Pinned messages
-	sb.WriteString("\n== Pinned Messages ==\n")
-	pinnedResult, err := services.API().MessagesSearch(tgCtx, &tg.MessagesSearchRequest{
-		Peer:   peer,
-		Q:      "",
-		Filter: &tg.InputMessagesFilterPinned{},
-		Limit:  20,
+// writePendingJoinRequests reports how many users are waiting for approval and lists the most recent ones.
+func writePendingJoinRequests(ctx context.Context, sb *strings.Builder, peer tg.InputPeerClass) {
+	result, err := services.API().MessagesGetChatInviteImporters(ctx, &tg.MessagesGetChatInviteImportersRequest{
+		Requested:  true,
+		Peer:       peer,
+		OffsetUser: &tg.InputUserEmpty{},
+		Limit:      10,
 	})
 	if err != nil {
-		fmt.Fprintf(&sb, "Failed to get pinned messages: %v\n", err)
-	} else {
-		pinned := extractMessages(tgCtx, pinnedResult)
-		if len(pinned) == 0 {
-			sb.WriteString("No pinned messages.\n")
-		} else {
-			sb.WriteString(formatMessages(pinned))
+		if tg.IsChatAdminRequired(err) {
+			sb.WriteString("Admin rights required to see join requests.\n")
+			return
 		}
+		fmt.Fprintf(sb, "Failed to get join requests: %v\n", err)
+		return
 	}
 
-	return mcp.NewToolResultText(sb.String()), nil
+	services.StorePeers(ctx, nil, result.Users)
+
+	if result.Count == 0 {
+		sb.WriteString("No pending join requests.\n")
+		return
+	}
+
+	users := make(map[int64]*tg.User, len(result.Users))
+	for _, u := range result.Users {
+		if user, ok := u.(*tg.User); ok {
+			users[user.ID] = user
+		}
+	}
+
+	fmt.Fprintf(sb, "Pending: %d\n", result.Count)
+	for _, imp := range result.Importers {
+		sb.WriteString("  ")
+		if user, ok := users[imp.UserID]; ok {
+			formatUserInline(sb, user)
+		} else {
+			fmt.Fprintf(sb, "User %d", imp.UserID)
+		}
+		fmt.Fprintf(sb, " requested %s", time.Unix(int64(imp.Date), 0).UTC().Format("2006-01-02 15:04:05"))
+		if imp.About != "" {
+			fmt.Fprintf(sb, ": %s", truncateText(imp.About, 100))
+		}
+		sb.WriteString("\n")
+	}
 }
 
 func handleForwardBulk(_ context.Context, _ mcp.CallToolRequest, input forwardBulkInput) (*mcp.CallToolResult, error) {
@@ -521,11 +972,70 @@ func handleForwardBulk(_ context.Context, _ mcp.CallToolRequest, input forwardBu
 			randomIDs[i] = randomID()
 		}
 
-		_, err = services.API().MessagesForwardMessages(tgCtx, &tg.MessagesForwardMessagesRequest{
-			FromPeer: fromPeer,
-			ToPeer:   toPeer,
-			ID:       ids,
-			RandomID: randomIDs,
+		err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+			_, err := services.API().MessagesForwardMessages(ctx, &tg.MessagesForwardMessagesRequest{
+				FromPeer: fromPeer,
+				ToPeer:   toPeer,
+				ID:       ids,
+				RandomID: randomIDs,
+			})
+			return err
+		})
+		if err != nil {
+			fmt.Fprintf(&sb, "\n  %s: FAILED (%v)", dest, err)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n  %s: OK", dest)
+		successCount++
+	}
+
+	fmt.Fprintf(&sb, "\n\nCompleted: %d/%d destinations succeeded.", successCount, len(destinations))
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleBatchSend(_ context.Context, _ mcp.CallToolRequest, input batchSendInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	var destinations []string
+	for _, dest := range strings.Split(input.Peers, ",") {
+		if dest = strings.TrimSpace(dest); dest != "" {
+			destinations = append(destinations, dest)
+		}
+	}
+	if len(destinations) == 0 {
+		return mcp.NewToolResultError("no destinations provided"), nil
+	}
+	if len(destinations) > 20 {
+		return mcp.NewToolResultError("too many destinations (max 20)"), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Sending message to %d destination(s):\n", len(destinations))
+
+	successCount := 0
+	for _, dest := range destinations {
+		peer, err := services.ResolvePeer(tgCtx, dest)
+		if err != nil {
+			fmt.Fprintf(&sb, "\n  %s: FAILED (resolve: %v)", dest, err)
+			continue
+		}
+
+		message := input.Message
+		if strings.Contains(message, "{") {
+			message = broadcastPlaceholders(tgCtx, peer).Replace(message)
+		}
+
+		req := &tg.MessagesSendMessageRequest{
+			Peer:     peer,
+			Message:  message,
+			RandomID: randomID(),
+		}
+
+		// Flood waits are retried per destination so one slow chat doesn't abort the batch
+		err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+			_, err := services.API().MessagesSendMessage(ctx, req)
+			return err
 		})
 		if err != nil {
 			fmt.Fprintf(&sb, "\n  %s: FAILED (%v)", dest, err)
@@ -540,6 +1050,93 @@ func handleForwardBulk(_ context.Context, _ mcp.CallToolRequest, input forwardBu
 	return mcp.NewToolResultText(sb.String()), nil
 }
 
+// broadcastPlaceholders builds a replacer for the per-destination placeholders of telegram_batch_send.
+// For groups and channels {first_name} falls back to the title; for users {chat_title} is the full name.
+func broadcastPlaceholders(ctx context.Context, peer tg.InputPeerClass) *strings.Replacer {
+	var title, firstName, lastName, username string
+
+	var user *tg.User
+	if _, ok := peer.(*tg.InputPeerSelf); ok {
+		user = services.Self()
+	} else if p := inputPeerToPeer(peer); p != nil {
+		if stored, err := storage.FindPeer(ctx, services.PeerStorage(), p); err == nil {
+			switch {
+			case stored.User != nil:
+				user = stored.User
+			case stored.Channel != nil:
+				title, username = stored.Channel.Title, stored.Channel.Username
+			case stored.Chat != nil:
+				title = stored.Chat.Title
+			}
+		}
+	}
+
+	if user != nil {
+		firstName, lastName, username = user.FirstName, user.LastName, user.Username
+		title = strings.TrimSpace(firstName + " " + lastName)
+	} else {
+		firstName = title
+	}
+
+	return strings.NewReplacer(
+		"{chat_title}", title,
+		"{first_name}", firstName,
+		"{last_name}", lastName,
+		"{username}", username,
+	)
+}
+
+func handleForwardWithEdit(_ context.Context, _ mcp.CallToolRequest, input forwardWithEditInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	fromPeer, err := services.ResolvePeer(tgCtx, input.FromPeer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve from_peer: %v", err)), nil
+	}
+
+	toPeer, err := services.ResolvePeer(tgCtx, input.ToPeer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve to_peer: %v", err)), nil
+	}
+
+	// Forwarded messages can't be edited, so drop the author to send an editable copy
+	forwardReq := &tg.MessagesForwardMessagesRequest{
+		FromPeer:   fromPeer,
+		ToPeer:     toPeer,
+		ID:         []int{input.MessageID},
+		RandomID:   []int64{randomID()},
+		DropAuthor: true,
+	}
+
+	var result tg.UpdatesClass
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		var err error
+		result, err = services.API().MessagesForwardMessages(ctx, forwardReq)
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to forward message: %v", err)), nil
+	}
+
+	newIDs := sentMessageIDs(result)
+	if len(newIDs) == 0 {
+		return mcp.NewToolResultError("message forwarded but its new ID could not be determined, text not edited"), nil
+	}
+
+	editReq := &tg.MessagesEditMessageRequest{
+		Peer: toPeer,
+		ID:   newIDs[0],
+	}
+	editReq.SetMessage(input.Text)
+
+	_, err = services.API().MessagesEditMessage(tgCtx, editReq)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("message forwarded (ID: %d) but failed to edit: %v", newIDs[0], err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Message forwarded and edited successfully (new message ID: %d).", newIDs[0])), nil
+}
+
 func handleExportMessages(_ context.Context, _ mcp.CallToolRequest, input exportMessagesInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -556,18 +1153,51 @@ func handleExportMessages(_ context.Context, _ mcp.CallToolRequest, input export
 		totalLimit = 500
 	}
 
+	allMessages, err := fetchHistory(tgCtx, peer, totalLimit, input.Since)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+	}
+
+	if len(allMessages) == 0 {
+		return mcp.NewToolResultText("No messages found."), nil
+	}
+
+	if input.Format == "csv" {
+		absDir, err := prepareOutputDir(input.OutputDir)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		name := filepath.Base(strings.TrimPrefix(input.Peer, "@"))
+		filePath := filepath.Join(absDir, fmt.Sprintf("messages_%s_%d.csv", name, time.Now().Unix()))
+		if err := writeMessagesCSV(tgCtx, filePath, allMessages); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to write csv: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Exported %d messages to: %s", len(allMessages), filePath)), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Exported %d messages:\n\n", len(allMessages))
+	sb.WriteString(formatMessages(tgCtx, allMessages))
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// fetchHistory pages backwards through a chat's history in batches of 100 until
+// limit messages are collected or a message older than since (if set) is reached.
+// If a later page fails, the messages fetched so far are returned.
+func fetchHistory(ctx context.Context, peer tg.InputPeerClass, limit, since int) ([]tg.MessageClass, error) {
 	var allMessages []tg.MessageClass
 	offsetID := 0
 	batchSize := 100
 
-	for len(allMessages) < totalLimit {
-		remaining := totalLimit - len(allMessages)
+	for len(allMessages) < limit {
+		remaining := limit - len(allMessages)
 		fetchLimit := batchSize
 		if remaining < fetchLimit {
 			fetchLimit = remaining
 		}
 
-		result, err := services.API().MessagesGetHistory(tgCtx, &tg.MessagesGetHistoryRequest{
+		result, err := services.API().MessagesGetHistory(ctx, &tg.MessagesGetHistoryRequest{
 			Peer:     peer,
 			Limit:    fetchLimit,
 			OffsetID: offsetID,
@@ -576,10 +1206,10 @@ func handleExportMessages(_ context.Context, _ mcp.CallToolRequest, input export
 			if len(allMessages) > 0 {
 				break // return what we have so far
 			}
-			return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+			return nil, err
 		}
 
-		msgs := extractMessages(tgCtx, result)
+		msgs := extractMessages(ctx, result)
 		if len(msgs) == 0 {
 			break
 		}
@@ -591,7 +1221,7 @@ func handleExportMessages(_ context.Context, _ mcp.CallToolRequest, input export
 			if !ok {
 				continue
 			}
-			if input.Since > 0 && msg.Date < input.Since {
+			if since > 0 && msg.Date < since {
 				hitSince = true
 				break
 			}
@@ -614,13 +1244,939 @@ func handleExportMessages(_ context.Context, _ mcp.CallToolRequest, input export
 		}
 	}
 
-	if len(allMessages) == 0 {
-		return mcp.NewToolResultText("No messages found."), nil
+	return allMessages, nil
+}
+
+// cleanInputPath validates a user-supplied file path to read: it must be absolute and free of ".." segments.
+func cleanInputPath(path string) (string, error) {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == ".." {
+			return "", fmt.Errorf("file_path must not contain '..'")
+		}
+	}
+	cleanPath := filepath.Clean(path)
+	if !filepath.IsAbs(cleanPath) {
+		return "", fmt.Errorf("file_path must be an absolute path")
+	}
+	return cleanPath, nil
+}
+
+// prepareOutputDir resolves an export directory (default ./downloads) to an absolute path and creates it.
+func prepareOutputDir(outputDir string) (string, error) {
+	if outputDir == "" {
+		outputDir = "./downloads"
+	}
+	absDir, err := filepath.Abs(filepath.Clean(outputDir))
+	if err != nil {
+		return "", fmt.Errorf("invalid output_dir: %w", err)
+	}
+	if err := os.MkdirAll(absDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create output dir: %w", err)
+	}
+	return absDir, nil
+}
+
+func writeMessagesCSV(ctx context.Context, path string, msgs []tg.MessageClass) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"message_id", "date", "sender_id", "sender_name", "reply_to", "media_type", "text"}); err != nil {
+		return err
+	}
+
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+
+		var senderID, senderName string
+		if msg.FromID != nil {
+			senderID = strconv.FormatInt(peerToID(msg.FromID), 10)
+			senderName = peerDisplayName(ctx, msg.FromID)
+		}
+
+		var replyTo string
+		if header, ok := msg.GetReplyTo(); ok {
+			if h, ok := header.(*tg.MessageReplyHeader); ok {
+				if id, ok := h.GetReplyToMsgID(); ok {
+					replyTo = strconv.Itoa(id)
+				}
+			}
+		}
+
+		if err := w.Write([]string{
+			strconv.Itoa(msg.ID),
+			time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05"),
+			senderID,
+			senderName,
+			replyTo,
+			mediaTypeName(msg.Media),
+			msg.Message,
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func handleGetScheduled(_ context.Context, _ mcp.CallToolRequest, input getScheduledInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetDialogs(tgCtx, &tg.MessagesGetDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get dialogs: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok {
+		return mcp.NewToolResultError("no dialogs returned"), nil
+	}
+
+	services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+	type scheduledMessage struct {
+		chat string
+		msg  *tg.Message
+	}
+
+	var scheduled []scheduledMessage
+	for _, dc := range modified.GetDialogs() {
+		d, ok := dc.(*tg.Dialog)
+		if !ok {
+			continue
+		}
+
+		peer, err := services.GetInputPeerByID(tgCtx, peerToID(d.Peer))
+		if err != nil {
+			continue
+		}
+
+		history, err := services.API().MessagesGetScheduledHistory(tgCtx, &tg.MessagesGetScheduledHistoryRequest{
+			Peer: peer,
+		})
+		if err != nil {
+			continue
+		}
+
+		chatName := senderLabel(tgCtx, d.Peer)
+		for _, mc := range extractMessages(tgCtx, history) {
+			if msg, ok := mc.(*tg.Message); ok {
+				scheduled = append(scheduled, scheduledMessage{chat: chatName, msg: msg})
+			}
+		}
 	}
 
+	if len(scheduled) == 0 {
+		return mcp.NewToolResultText("No scheduled messages found."), nil
+	}
+
+	// Scheduled messages carry their send time in Date
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].msg.Date < scheduled[j].msg.Date
+	})
+
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Exported %d messages:\n\n", len(allMessages))
-	sb.WriteString(formatMessages(allMessages))
+	fmt.Fprintf(&sb, "Scheduled messages (%d):\n", len(scheduled))
+	for _, sm := range scheduled {
+		t := time.Unix(int64(sm.msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+		text := truncateText(sm.msg.Message, 200)
+		if text == "" && sm.msg.Media != nil {
+			text = fmt.Sprintf("[%s]", mediaTypeName(sm.msg.Media))
+		}
+		fmt.Fprintf(&sb, "\n[%s] %s, message %d: %s", t, sm.chat, sm.msg.ID, text)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetMyStats(_ context.Context, _ mcp.CallToolRequest, _ getMyStatsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	var sb strings.Builder
+	sb.WriteString("=== Account Overview ===\n")
+	formatUserInline(&sb, services.Self())
+	sb.WriteString("\n\n")
+
+	dialogsResult, err := services.API().MessagesGetDialogs(tgCtx, &tg.MessagesGetDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      100,
+	})
+	if err != nil {
+		fmt.Fprintf(&sb, "Dialogs: unavailable (%v)\n", err)
+	} else if modified, ok := dialogsResult.AsModified(); ok {
+		services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+		dialogs := modified.GetDialogs()
+		total := len(dialogs)
+		if slice, ok := dialogsResult.(*tg.MessagesDialogsSlice); ok {
+			total = slice.Count
+		}
+
+		unreadMessages, unreadChats := 0, 0
+		for _, d := range dialogs {
+			dialog, ok := d.(*tg.Dialog)
+			if !ok {
+				continue
+			}
+			if dialog.UnreadCount > 0 || dialog.UnreadMark {
+				unreadChats++
+			}
+			unreadMessages += dialog.UnreadCount
+		}
+
+		fmt.Fprintf(&sb, "Dialogs: %d\n", total)
+		fmt.Fprintf(&sb, "Unread: %d message(s) in %d chat(s)", unreadMessages, unreadChats)
+		if total > len(dialogs) {
+			fmt.Fprintf(&sb, " (across the %d most recent dialogs)", len(dialogs))
+		}
+		sb.WriteString("\n")
+	}
+
+	contactsResult, err := services.API().ContactsGetContacts(tgCtx, 0)
+	if err != nil {
+		fmt.Fprintf(&sb, "Contacts: unavailable (%v)\n", err)
+	} else if contacts, ok := contactsResult.(*tg.ContactsContacts); ok {
+		services.StorePeers(tgCtx, nil, contacts.Users)
+		fmt.Fprintf(&sb, "Contacts: %d\n", len(contacts.Contacts))
+	}
+
+	filters, err := services.API().MessagesGetDialogFilters(tgCtx)
+	if err != nil {
+		fmt.Fprintf(&sb, "Folders: unavailable (%v)\n", err)
+	} else {
+		folderCount := 0
+		for _, f := range filters.Filters {
+			if _, isDefault := f.(*tg.DialogFilterDefault); !isDefault {
+				folderCount++
+			}
+		}
+		fmt.Fprintf(&sb, "Folders: %d\n", folderCount)
+	}
+
+	blockedResult, err := services.API().ContactsGetBlocked(tgCtx, &tg.ContactsGetBlockedRequest{Limit: 1})
+	if err != nil {
+		fmt.Fprintf(&sb, "Blocked: unavailable (%v)\n", err)
+	} else {
+		switch b := blockedResult.(type) {
+		case *tg.ContactsBlocked:
+			fmt.Fprintf(&sb, "Blocked: %d\n", len(b.Blocked))
+		case *tg.ContactsBlockedSlice:
+			fmt.Fprintf(&sb, "Blocked: %d\n", b.Count)
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// resolvePeerOrPhone resolves identifier like ResolvePeer, but looks up "+"-prefixed
+// phone numbers with ContactsResolvePhone, since ResolvePeer would read them as IDs.
+func resolvePeerOrPhone(ctx context.Context, identifier string) (tg.InputPeerClass, error) {
+	if !strings.HasPrefix(identifier, "+") {
+		return services.ResolvePeer(ctx, identifier)
+	}
+
+	resolved, err := services.API().ContactsResolvePhone(ctx, strings.TrimPrefix(identifier, "+"))
+	if err != nil {
+		return nil, err
+	}
+	services.StorePeers(ctx, resolved.Chats, resolved.Users)
+	return services.GetInputPeerByID(ctx, peerToID(resolved.Peer))
+}
+
+func handleWhois(_ context.Context, _ mcp.CallToolRequest, input whoisInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := resolvePeerOrPhone(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	var sb strings.Builder
+
+	switch p := peer.(type) {
+	case *tg.InputPeerUser, *tg.InputPeerSelf:
+		inputUser := &tg.InputUser{}
+		if u, ok := p.(*tg.InputPeerUser); ok {
+			inputUser.UserID, inputUser.AccessHash = u.UserID, u.AccessHash
+		} else {
+			self := services.Self()
+			inputUser.UserID, inputUser.AccessHash = self.ID, self.AccessHash
+		}
+
+		fullResult, err := services.API().UsersGetFullUser(tgCtx, inputUser)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get user info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+
+		for _, u := range fullResult.Users {
+			user, ok := u.(*tg.User)
+			if !ok || user.ID != inputUser.UserID {
+				continue
+			}
+			formatUser(&sb, user)
+			if user.Premium {
+				sb.WriteString("Premium: yes\n")
+			}
+			if status, ok := user.GetEmojiStatus(); ok {
+				formatEmojiStatus(&sb, status)
+			}
+			if status, ok := user.GetStatus(); ok {
+				if presence := formatUserStatus(status); presence != "" {
+					fmt.Fprintf(&sb, "Last Seen: %s\n", presence)
+				}
+			}
+			switch {
+			case user.MutualContact:
+				sb.WriteString("Contact: yes (mutual)\n")
+			case user.Contact:
+				sb.WriteString("Contact: yes\n")
+			default:
+				sb.WriteString("Contact: no\n")
+			}
+			break
+		}
+
+		full := &fullResult.FullUser
+		if full.About != "" {
+			fmt.Fprintf(&sb, "Bio: %s\n", full.About)
+		}
+		if full.Blocked {
+			sb.WriteString("Blocked: yes\n")
+		} else {
+			sb.WriteString("Blocked: no\n")
+		}
+		stories := 0
+		if ps, ok := full.GetStories(); ok {
+			stories = len(ps.Stories)
+		}
+		fmt.Fprintf(&sb, "Active Stories: %d\n", stories)
+
+		fmt.Fprintf(&sb, "\n== Common Chats (%d) ==\n", full.CommonChatsCount)
+		if full.CommonChatsCount > 0 {
+			common, err := services.API().MessagesGetCommonChats(tgCtx, &tg.MessagesGetCommonChatsRequest{
+				UserID: inputUser,
+				Limit:  100,
+			})
+			if err != nil {
+				fmt.Fprintf(&sb, "Failed to get common chats: %v\n", err)
+			} else {
+				chats := common.GetChats()
+				services.StorePeers(tgCtx, chats, nil)
+				for _, c := range chats {
+					switch ch := c.(type) {
+					case *tg.Chat:
+						fmt.Fprintf(&sb, "  %s (ID: %d, group)\n", ch.Title, ch.ID)
+					case *tg.Channel:
+						fmt.Fprintf(&sb, "  %s (ID: %d", ch.Title, ch.ID)
+						if ch.Username != "" {
+							fmt.Fprintf(&sb, ", @%s", ch.Username)
+						}
+						sb.WriteString(")\n")
+					}
+				}
+			}
+		}
+
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(tgCtx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get channel info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+
+		for _, c := range fullResult.Chats {
+			if ch, ok := c.(*tg.Channel); ok && ch.ID == p.ChannelID {
+				formatChat(&sb, ch)
+				if ch.Left {
+					sb.WriteString("Joined: no\n")
+				} else {
+					sb.WriteString("Joined: yes\n")
+				}
+				break
+			}
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
+			if full.About != "" {
+				fmt.Fprintf(&sb, "Description: %s\n", full.About)
+			}
+			if count, ok := full.GetParticipantsCount(); ok {
+				fmt.Fprintf(&sb, "Members: %d\n", count)
+			}
+			if count, ok := full.GetOnlineCount(); ok {
+				fmt.Fprintf(&sb, "Online: %d\n", count)
+			}
+			stories := 0
+			if ps, ok := full.GetStories(); ok {
+				stories = len(ps.Stories)
+			}
+			fmt.Fprintf(&sb, "Active Stories: %d\n", stories)
+		}
+
+	case *tg.InputPeerChat:
+		fullResult, err := services.API().MessagesGetFullChat(tgCtx, p.ChatID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+
+		for _, c := range fullResult.Chats {
+			if ch, ok := c.(*tg.Chat); ok && ch.ID == p.ChatID {
+				formatChat(&sb, ch)
+				break
+			}
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChatFull); ok && full.About != "" {
+			fmt.Fprintf(&sb, "Description: %s\n", full.About)
+		}
+
+	default:
+		return mcp.NewToolResultError("unsupported peer type"), nil
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetChatTimeline(_ context.Context, _ mcp.CallToolRequest, input chatTimelineInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 500
+	}
+	if limit > 2000 {
+		limit = 2000
+	}
+
+	var (
+		keyFormat string
+		keys      []string
+	)
+	switch input.Bucket {
+	case "", "hour":
+		keyFormat = "15:00"
+		for h := 0; h < 24; h++ {
+			keys = append(keys, fmt.Sprintf("%02d:00", h))
+		}
+	case "weekday":
+		keyFormat = "Monday"
+		for d := time.Monday; d <= time.Saturday; d++ {
+			keys = append(keys, d.String())
+		}
+		keys = append(keys, time.Sunday.String())
+	case "day":
+		keyFormat = "2006-01-02"
+	default:
+		return mcp.NewToolResultError("bucket must be hour, weekday, or day"), nil
+	}
+
+	msgs, err := fetchHistory(tgCtx, peer, limit, 0)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+	}
+
+	counts := make(map[string]int)
+	senders := make(map[string]map[string]int)
+	totals := make(map[string]int)
+	names := make(map[senderKey]string)
+	var analyzed, oldest, newest int
+
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+
+		sender := cachedSenderLabel(tgCtx, names, msg)
+
+		key := time.Unix(int64(msg.Date), 0).UTC().Format(keyFormat)
+		counts[key]++
+		if senders[key] == nil {
+			senders[key] = make(map[string]int)
+		}
+		senders[key][sender]++
+		totals[sender]++
+
+		analyzed++
+		if oldest == 0 || msg.Date < oldest {
+			oldest = msg.Date
+		}
+		if msg.Date > newest {
+			newest = msg.Date
+		}
+	}
+
+	if analyzed == 0 {
+		return mcp.NewToolResultText("No messages found."), nil
+	}
+
+	if keys == nil {
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+
+	peak := 0
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Activity for %d messages from %s to %s (UTC):\n\n",
+		analyzed,
+		time.Unix(int64(oldest), 0).UTC().Format("2006-01-02 15:04:05"),
+		time.Unix(int64(newest), 0).UTC().Format("2006-01-02 15:04:05"),
+	)
+
+	for _, k := range keys {
+		c := counts[k]
+		bar := strings.Repeat("#", (c*30+peak-1)/peak)
+		fmt.Fprintf(&sb, "%-10s %5d %-30s", k, c, bar)
+		if top := topCounts(senders[k], 3); len(top) > 0 {
+			fmt.Fprintf(&sb, " %s", strings.Join(top, ", "))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nTop posters:\n")
+	for _, t := range topCounts(totals, 10) {
+		fmt.Fprintf(&sb, "  %s\n", t)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleFindUnansweredQuestions(_ context.Context, _ mcp.CallToolRequest, input findUnansweredInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	msgs, err := fetchHistory(tgCtx, peer, limit, 0)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+	}
+
+	replied := make(map[int]bool)
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+		if header, ok := msg.GetReplyTo(); ok {
+			if h, ok := header.(*tg.MessageReplyHeader); ok {
+				if id, ok := h.GetReplyToMsgID(); ok {
+					replied[id] = true
+				}
+			}
+		}
+	}
+
+	// History is newest first; collect oldest first so the output reads chronologically
+	var unanswered []tg.MessageClass
+	for i := len(msgs) - 1; i >= 0; i-- {
+		msg, ok := msgs[i].(*tg.Message)
+		if !ok || msg.Out || replied[msg.ID] || !isQuestion(msg.Message) {
+			continue
+		}
+		unanswered = append(unanswered, msg)
+	}
+
+	if len(unanswered) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No unanswered questions in the last %d messages.", len(msgs))), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Unanswered questions (%d in the last %d messages):\n", len(unanswered), len(msgs))
+	sb.WriteString(formatMessages(tgCtx, unanswered))
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetNewMembers(_ context.Context, _ mcp.CallToolRequest, input getNewMembersInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	inputChannel, ok := toInputChannel(peer)
+	if !ok {
+		return mcp.NewToolResultError("peer is not a supergroup"), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	type newMember struct {
+		userID int64
+		date   int
+		via    string
+	}
+
+	var members []newMember
+	seen := make(map[int64]bool)
+	userMap := make(map[int64]*tg.User)
+	var maxID int64
+
+	// The admin log is returned newest first, so page back until we pass since
+	for done := false; !done && len(members) < limit; {
+		result, err := services.API().ChannelsGetAdminLog(tgCtx, &tg.ChannelsGetAdminLogRequest{
+			Channel:      inputChannel,
+			EventsFilter: tg.ChannelAdminLogEventsFilter{Join: true, Invite: true},
+			MaxID:        maxID,
+			Limit:        100,
+		})
+		if err != nil {
+			if len(members) > 0 {
+				break
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get admin log: %v", err)), nil
+		}
+
+		services.StorePeers(tgCtx, result.Chats, result.Users)
+		for _, u := range result.Users {
+			if user, ok := u.(*tg.User); ok {
+				userMap[user.ID] = user
+			}
+		}
+
+		if len(result.Events) == 0 {
+			break
+		}
+
+		for _, event := range result.Events {
+			if event.Date < input.Since {
+				done = true
+				break
+			}
+
+			userID, via := event.UserID, ""
+			switch a := event.Action.(type) {
+			case *tg.ChannelAdminLogEventActionParticipantJoin:
+				via = "joined"
+			case *tg.ChannelAdminLogEventActionParticipantJoinByInvite:
+				via = "joined via invite link"
+				if invite, ok := a.Invite.(*tg.ChatInviteExported); ok {
+					via += " " + invite.Link
+				}
+			case *tg.ChannelAdminLogEventActionParticipantJoinByRequest:
+				via = "join request approved by " + senderLabel(tgCtx, &tg.PeerUser{UserID: a.ApprovedBy})
+			case *tg.ChannelAdminLogEventActionParticipantInvite:
+				userID = participantUserID(a.Participant)
+				via = "added by " + senderLabel(tgCtx, &tg.PeerUser{UserID: event.UserID})
+			default:
+				continue
+			}
+
+			if userID == 0 || seen[userID] {
+				continue
+			}
+			seen[userID] = true
+			members = append(members, newMember{userID: userID, date: event.Date, via: via})
+			if len(members) >= limit {
+				break
+			}
+		}
+
+		maxID = result.Events[len(result.Events)-1].ID
+		if len(result.Events) < 100 {
+			break
+		}
+	}
+
+	since := time.Unix(int64(input.Since), 0).UTC().Format("2006-01-02 15:04:05")
+	if len(members) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No new members since %s.", since)), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "New members since %s (%d):\n", since, len(members))
+	for i := len(members) - 1; i >= 0; i-- {
+		m := members[i]
+		t := time.Unix(int64(m.date), 0).UTC().Format("2006-01-02 15:04:05")
+		sb.WriteString("  ")
+		if user, ok := userMap[m.userID]; ok {
+			formatUserInline(&sb, user)
+		} else {
+			fmt.Fprintf(&sb, "ID: %d", m.userID)
+		}
+		fmt.Fprintf(&sb, " | %s | %s\n", t, m.via)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// spamLinkThreshold is the number of links in one message that marks it as a spam candidate.
+const spamLinkThreshold = 3
+
+func handleDetectSpamCandidates(_ context.Context, _ mcp.CallToolRequest, input detectSpamInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	msgs, err := fetchHistory(tgCtx, peer, limit, 0)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+	}
+
+	// Count distinct senders per normalized text to catch copy-pasted spam
+	textSenders := make(map[string]map[int64]bool)
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+		text := strings.ToLower(strings.Join(strings.Fields(msg.Message), " "))
+		if len(text) < 10 {
+			continue
+		}
+		if textSenders[text] == nil {
+			textSenders[text] = make(map[int64]bool)
+		}
+		textSenders[text][peerToID(msg.FromID)] = true
+	}
+
+	var sb strings.Builder
+	flagged := 0
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok || msg.Out {
+			continue
+		}
+
+		var reasons []string
+
+		links := 0
+		for _, e := range msg.Entities {
+			switch e.(type) {
+			case *tg.MessageEntityURL, *tg.MessageEntityTextURL:
+				links++
+			}
+		}
+		if links >= spamLinkThreshold {
+			reasons = append(reasons, fmt.Sprintf("%d links", links))
+		}
+
+		if isShouting(msg.Message) {
+			reasons = append(reasons, "all caps")
+		}
+
+		text := strings.ToLower(strings.Join(strings.Fields(msg.Message), " "))
+		if n := len(textSenders[text]); n > 1 {
+			reasons = append(reasons, fmt.Sprintf("same text posted by %d users", n))
+		}
+
+		if msg.FromID != nil {
+			if stored, err := storage.FindPeer(tgCtx, services.PeerStorage(), msg.FromID); err == nil && stored.User != nil {
+				if stored.User.Scam {
+					reasons = append(reasons, "sender marked as scam")
+				}
+				if stored.User.Fake {
+					reasons = append(reasons, "sender marked as fake")
+				}
+			}
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		flagged++
+		t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+		fmt.Fprintf(&sb, "\n[%d] %s (%s): %s\n", msg.ID, senderLabel(tgCtx, messageSender(msg)), t, truncateText(msg.Message, 200))
+		fmt.Fprintf(&sb, "  Reasons: %s\n", strings.Join(reasons, ", "))
+	}
+
+	if flagged == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No spam candidates in the last %d messages.", len(msgs))), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Spam candidates (%d in the last %d messages):\n", flagged, len(msgs)) + sb.String()), nil
+}
+
+// isShouting reports whether a message is written mostly in capital letters.
+// Short messages are ignored, since acronyms and interjections are common.
+func isShouting(text string) bool {
+	upper, letters := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	return letters >= 10 && upper*10 >= letters*8
+}
+
+// questionWords are sentence openers that mark a message as a question even without a '?'.
+var questionWords = []string{
+	"who", "what", "when", "where", "why", "how", "which",
+	"is", "are", "can", "could", "does", "do", "did", "should", "would", "will",
+	"anyone", "anybody", "has anyone", "is there",
+}
+
+func isQuestion(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	if strings.HasSuffix(text, "?") || strings.HasSuffix(text, "？") {
+		return true
+	}
+
+	lower := strings.ToLower(text)
+	for _, w := range questionWords {
+		if rest, ok := strings.CutPrefix(lower, w); ok && (rest == "" || strings.IndexAny(rest[:1], " ,'") == 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// topCounts returns up to n "name (count)" entries, highest count first.
+func topCounts(m map[string]int, n int) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if m[names[i]] != m[names[j]] {
+			return m[names[i]] > m[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = fmt.Sprintf("%s (%d)", name, m[name])
+	}
+	return out
+}
+
+// formatUserStatus describes a user's last-seen presence, or returns an empty string if it's hidden.
+func formatUserStatus(status tg.UserStatusClass) string {
+	switch st := status.(type) {
+	case *tg.UserStatusOnline:
+		return "online"
+	case *tg.UserStatusOffline:
+		return time.Unix(int64(st.WasOnline), 0).UTC().Format("2006-01-02 15:04:05")
+	case *tg.UserStatusRecently:
+		return "recently"
+	case *tg.UserStatusLastWeek:
+		return "within a week"
+	case *tg.UserStatusLastMonth:
+		return "within a month"
+	default:
+		return ""
+	}
+}
+
+func handleReportSpamAndBlock(_ context.Context, _ mcp.CallToolRequest, input reportSpamAndBlockInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+	if _, ok := peer.(*tg.InputPeerUser); !ok {
+		return mcp.NewToolResultError("peer is not a user; use telegram_leave_chat for groups and channels"), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Handling %s as spam:", inputPeerLabel(tgCtx, peer))
+
+	// Telegram only offers reporting for chats started by strangers; note it when the hint is absent
+	if settings, err := services.API().MessagesGetPeerSettings(tgCtx, peer); err == nil && !settings.Settings.ReportSpam {
+		sb.WriteString("\n  Note: Telegram does not suggest reporting this chat (it may be a contact or an old conversation)")
+	}
+
+	steps := 0
+	succeeded := 0
+	step := func(name string, fn func() error) {
+		steps++
+		if err := fn(); err != nil {
+			fmt.Fprintf(&sb, "\n  %s: FAILED (%v)", name, err)
+			return
+		}
+		fmt.Fprintf(&sb, "\n  %s: OK", name)
+		succeeded++
+	}
+
+	step("Report spam", func() error {
+		_, err := services.API().MessagesReportSpam(tgCtx, peer)
+		return err
+	})
+	step("Block", func() error {
+		_, err := services.API().ContactsBlock(tgCtx, &tg.ContactsBlockRequest{ID: peer})
+		return err
+	})
+	if input.DeleteChat {
+		step("Delete chat", func() error {
+			return deleteHistoryFully(tgCtx, &tg.MessagesDeleteHistoryRequest{Peer: peer})
+		})
+	}
+
+	fmt.Fprintf(&sb, "\n\nCompleted: %d/%d steps succeeded.", succeeded, steps)
 	return mcp.NewToolResultText(sb.String()), nil
 }
 
@@ -678,7 +2234,7 @@ func handleSearchCrossChat(_ context.Context, _ mcp.CallToolRequest, input searc
 		if len(msgs) == 0 {
 			sb.WriteString("  No results.\n")
 		} else {
-			sb.WriteString(formatMessages(msgs))
+			sb.WriteString(formatMessages(tgCtx, msgs))
 			totalResults += len(msgs)
 		}
 	}
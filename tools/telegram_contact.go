@@ -2,8 +2,14 @@ package tools
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gotd/td/tg"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,11 +25,53 @@ type importContactsInput struct {
 	LastName  string `json:"last_name"`
 }
 
+type importContactsBulkInput struct {
+	FilePath string `json:"file_path" jsonschema:"required"`
+}
+
+// importContactsRetries is how many times contacts Telegram asks to retry are re-imported.
+const importContactsRetries = 3
+
 type blockPeerInput struct {
 	Peer    string `json:"peer" jsonschema:"required"`
 	Unblock bool   `json:"unblock"`
 }
 
+type reportProfilePhotoInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	PhotoID   string `json:"photo_id"`
+	MessageID int    `json:"message_id"`
+	Reason    string `json:"reason" jsonschema:"required"`
+	Comment   string `json:"comment"`
+}
+
+// reportReasons maps reason names to the report reasons Telegram accepts for profile photos.
+var reportReasons = map[string]tg.ReportReasonClass{
+	"spam":             &tg.InputReportReasonSpam{},
+	"violence":         &tg.InputReportReasonViolence{},
+	"pornography":      &tg.InputReportReasonPornography{},
+	"child_abuse":      &tg.InputReportReasonChildAbuse{},
+	"copyright":        &tg.InputReportReasonCopyright{},
+	"fake":             &tg.InputReportReasonFake{},
+	"illegal_drugs":    &tg.InputReportReasonIllegalDrugs{},
+	"personal_details": &tg.InputReportReasonPersonalDetails{},
+	"geo_irrelevant":   &tg.InputReportReasonGeoIrrelevant{},
+	"other":            &tg.InputReportReasonOther{},
+}
+
+type getContactsStatusesInput struct {
+	OnlineOnly bool `json:"online_only"`
+}
+
+type getPeerSettingsInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+type getTopPeersInput struct {
+	Category string `json:"category"`
+	Limit    int    `json:"limit"`
+}
+
 func RegisterContactTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_get_contacts",
@@ -36,7 +84,7 @@ func RegisterContactTools(s *server.MCPServer) {
 
 	s.AddTool(
 		mcp.NewTool("telegram_import_contacts",
-			mcp.WithDescription("Import a contact by phone number"),
+			mcp.WithDescription("Import a contact by phone number and report whether the number has a Telegram account. Imports Telegram asks to retry are re-attempted automatically"),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithString("phone", mcp.Required(), mcp.Description("Phone number in international format")),
@@ -46,6 +94,16 @@ func RegisterContactTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleImportContacts),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_import_contacts_bulk",
+			mcp.WithDescription("Import contacts from a CSV file with columns phone, first_name, last_name, and report which numbers have a Telegram account"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("file_path", mcp.Required(), mcp.Description("Absolute path to the CSV file (a header row is optional)")),
+		),
+		mcp.NewTypedToolHandler(handleImportContactsBulk),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_block_peer",
 			mcp.WithDescription("Block or unblock a user"),
@@ -56,6 +114,51 @@ func RegisterContactTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleBlockPeer),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_report_profile_photo",
+			mcp.WithDescription("Report an abusive profile photo, or a photo/media message, to Telegram moderators"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("User, group or channel ID or @username")),
+			mcp.WithString("photo_id", mcp.Description("ID of the profile photo to report (default: the current profile photo)")),
+			mcp.WithNumber("message_id", mcp.Description("Report the media in this message instead of a profile photo")),
+			mcp.WithString("reason", mcp.Required(), mcp.Description("Reason: spam, violence, pornography, child_abuse, copyright, fake, illegal_drugs, personal_details, geo_irrelevant, or other")),
+			mcp.WithString("comment", mcp.Description("Additional details for the moderators (optional)")),
+		),
+		mcp.NewTypedToolHandler(handleReportProfilePhoto),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_top_peers",
+			mcp.WithDescription("Get the peers you interact with most, by category, with their rating"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("category", mcp.Description("Category: correspondents, groups, channels, bots, or all (default all)")),
+			mcp.WithNumber("limit", mcp.Description("Maximum peers per category (default 10, max 50)")),
+		),
+		mcp.NewTypedToolHandler(handleGetTopPeers),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_contacts_statuses",
+			mcp.WithDescription("Get the online / last seen status of all contacts in one call, most recently active first"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithBoolean("online_only", mcp.Description("Only list contacts who are online now (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleGetContactsStatuses),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_peer_settings",
+			mcp.WithDescription("Get the actions Telegram suggests for a chat, such as report spam, block or add contact. These hints show up for unsolicited messages from strangers"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("User or chat ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleGetPeerSettings),
+	)
 }
 
 func handleGetContacts(_ context.Context, _ mcp.CallToolRequest, input getContactsInput) (*mcp.CallToolResult, error) {
@@ -95,36 +198,173 @@ func handleGetContacts(_ context.Context, _ mcp.CallToolRequest, input getContac
 func handleImportContacts(_ context.Context, _ mcp.CallToolRequest, input importContactsInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
-	result, err := services.API().ContactsImportContacts(tgCtx, []tg.InputPhoneContact{
+	contacts := []tg.InputPhoneContact{
 		{
 			ClientID:  randomID(),
 			Phone:     input.Phone,
 			FirstName: input.FirstName,
 			LastName:  input.LastName,
 		},
-	})
+	}
+
+	report, err := importContacts(tgCtx, contacts)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to import contact: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("failed to import contacts: %v", err)), nil
+	}
+	return mcp.NewToolResultText(report), nil
+}
+
+// importContacts imports contacts in one call, re-importing the ones Telegram asks to retry with backoff,
+// and reports which numbers resolved to accounts.
+func importContacts(ctx context.Context, contacts []tg.InputPhoneContact) (string, error) {
+	resolved := make(map[int64]int64, len(contacts)) // client ID -> user ID
+	users := make(map[int64]*tg.User)
+	pending := contacts
+	backoff := time.Second
+
+	for attempt := 0; attempt <= importContactsRetries && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		var result *tg.ContactsImportedContacts
+		err := services.WithFloodRetry(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = services.API().ContactsImportContacts(ctx, pending)
+			return err
+		})
+		if err != nil {
+			if len(resolved) == 0 {
+				return "", err
+			}
+			break
+		}
+
+		services.StorePeers(ctx, nil, result.Users)
+		for _, u := range result.Users {
+			if user, ok := u.(*tg.User); ok {
+				users[user.ID] = user
+			}
+		}
+		for _, imp := range result.Imported {
+			resolved[imp.ClientID] = imp.UserID
+		}
+
+		retry := make(map[int64]bool, len(result.RetryContacts))
+		for _, id := range result.RetryContacts {
+			retry[id] = true
+		}
+		var next []tg.InputPhoneContact
+		for _, c := range pending {
+			if retry[c.ClientID] {
+				next = append(next, c)
+			}
+		}
+		pending = next
 	}
 
-	services.StorePeers(tgCtx, nil, result.Users)
+	stillPending := make(map[int64]bool, len(pending))
+	for _, c := range pending {
+		stillPending[c.ClientID] = true
+	}
 
 	var b strings.Builder
-	fmt.Fprintf(&b, "Imported: %d\n", len(result.Imported))
+	fmt.Fprintf(&b, "Imported: %d/%d\n", len(resolved), len(contacts))
+	var missing, retryLater []string
+	for _, c := range contacts {
+		userID, ok := resolved[c.ClientID]
+		switch {
+		case ok:
+			fmt.Fprintf(&b, "  %s -> ", c.Phone)
+			if user, found := users[userID]; found {
+				formatUserInline(&b, user)
+			} else {
+				fmt.Fprintf(&b, "User ID: %d", userID)
+			}
+			b.WriteString("\n")
+		case stillPending[c.ClientID]:
+			retryLater = append(retryLater, c.Phone)
+		default:
+			missing = append(missing, c.Phone)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "No Telegram account (or hidden by privacy settings): %s\n", strings.Join(missing, ", "))
+	}
+	if len(retryLater) > 0 {
+		fmt.Fprintf(&b, "Still rate-limited after %d retries, try again later: %s\n", importContactsRetries, strings.Join(retryLater, ", "))
+	}
 
-	for _, u := range result.Users {
-		user, ok := u.(*tg.User)
-		if !ok {
+	return b.String(), nil
+}
+
+func handleImportContactsBulk(_ context.Context, _ mcp.CallToolRequest, input importContactsBulkInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	cleanPath, err := cleanInputPath(input.FilePath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	contacts, err := readContactsCSV(cleanPath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report, err := importContacts(tgCtx, contacts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to import contacts: %v", err)), nil
+	}
+	return mcp.NewToolResultText(report), nil
+}
+
+// readContactsCSV reads phone, first_name, last_name rows, skipping a header row if present.
+func readContactsCSV(path string) ([]tg.InputPhoneContact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %v", err)
+	}
+
+	var contacts []tg.InputPhoneContact
+	for i, rec := range records {
+		if i == 0 && len(rec) > 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "phone") {
 			continue
 		}
-		fmt.Fprintf(&b, "User ID: %d\n", user.ID)
+		if len(rec) == 0 || (len(rec) == 1 && strings.TrimSpace(rec[0]) == "") {
+			continue
+		}
+		if len(rec) < 2 || strings.TrimSpace(rec[0]) == "" || strings.TrimSpace(rec[1]) == "" {
+			return nil, fmt.Errorf("invalid contact on line %d: expected phone,first_name[,last_name]", i+1)
+		}
+		c := tg.InputPhoneContact{
+			ClientID:  randomID(),
+			Phone:     strings.TrimSpace(rec[0]),
+			FirstName: strings.TrimSpace(rec[1]),
+		}
+		if len(rec) > 2 {
+			c.LastName = strings.TrimSpace(rec[2])
+		}
+		contacts = append(contacts, c)
 	}
 
-	if len(result.RetryContacts) > 0 {
-		fmt.Fprintf(&b, "Retry contacts: %d\n", len(result.RetryContacts))
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("no contacts found in file")
 	}
-
-	return mcp.NewToolResultText(b.String()), nil
+	return contacts, nil
 }
 
 func handleBlockPeer(_ context.Context, _ mcp.CallToolRequest, input blockPeerInput) (*mcp.CallToolResult, error) {
@@ -158,3 +398,359 @@ func handleBlockPeer(_ context.Context, _ mcp.CallToolRequest, input blockPeerIn
 	}
 	return mcp.NewToolResultText(fmt.Sprintf("Peer %s successfully.", action)), nil
 }
+
+func handleGetTopPeers(_ context.Context, _ mcp.CallToolRequest, input getTopPeersInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	req := &tg.ContactsGetTopPeersRequest{Limit: limit}
+	switch input.Category {
+	case "correspondents":
+		req.Correspondents = true
+	case "groups":
+		req.Groups = true
+	case "channels":
+		req.Channels = true
+	case "bots":
+		req.BotsPm = true
+	case "", "all":
+		req.Correspondents = true
+		req.Groups = true
+		req.Channels = true
+		req.BotsPm = true
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("unknown category %q", input.Category)), nil
+	}
+
+	result, err := services.API().ContactsGetTopPeers(tgCtx, req)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get top peers: %v", err)), nil
+	}
+
+	topPeers, ok := result.(*tg.ContactsTopPeers)
+	if !ok {
+		if _, disabled := result.(*tg.ContactsTopPeersDisabled); disabled {
+			return mcp.NewToolResultText("Top peers are disabled in your privacy settings."), nil
+		}
+		return mcp.NewToolResultText("No top peers found."), nil
+	}
+
+	services.StorePeers(tgCtx, topPeers.Chats, topPeers.Users)
+
+	var b strings.Builder
+	for _, category := range topPeers.Categories {
+		fmt.Fprintf(&b, "%s (%d):\n", topPeerCategoryName(category.Category), len(category.Peers))
+		for i, tp := range category.Peers {
+			fmt.Fprintf(&b, "  %d. %s (ID: %d) - rating %.2f\n", i+1, senderLabel(tgCtx, tp.Peer), peerToID(tp.Peer), tp.Rating)
+		}
+		b.WriteString("\n")
+	}
+
+	if b.Len() == 0 {
+		return mcp.NewToolResultText("No top peers found."), nil
+	}
+	return mcp.NewToolResultText(strings.TrimSpace(b.String())), nil
+}
+
+func topPeerCategoryName(category tg.TopPeerCategoryClass) string {
+	switch category.(type) {
+	case *tg.TopPeerCategoryCorrespondents:
+		return "Correspondents"
+	case *tg.TopPeerCategoryGroups:
+		return "Groups"
+	case *tg.TopPeerCategoryChannels:
+		return "Channels"
+	case *tg.TopPeerCategoryBotsPM:
+		return "Bots"
+	case *tg.TopPeerCategoryBotsInline:
+		return "Inline bots"
+	default:
+		return category.TypeName()
+	}
+}
+
+func handleReportProfilePhoto(_ context.Context, _ mcp.CallToolRequest, input reportProfilePhotoInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	reason, ok := reportReasons[strings.ToLower(strings.TrimSpace(input.Reason))]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown reason %q", input.Reason)), nil
+	}
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	if input.MessageID > 0 {
+		return reportMessageMedia(tgCtx, peer, input)
+	}
+
+	photos, err := profilePhotos(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get profile photos: %v", err)), nil
+	}
+	if len(photos) == 0 {
+		return mcp.NewToolResultError("peer has no profile photo"), nil
+	}
+
+	photo := photos[0]
+	if input.PhotoID != "" {
+		photo = nil
+		for _, p := range photos {
+			if strconv.FormatInt(p.ID, 10) == input.PhotoID {
+				photo = p
+				break
+			}
+		}
+		if photo == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("profile photo %s not found", input.PhotoID)), nil
+		}
+	}
+
+	_, err = services.API().AccountReportProfilePhoto(tgCtx, &tg.AccountReportProfilePhotoRequest{
+		Peer:    peer,
+		PhotoID: photo.AsInput(),
+		Reason:  reason,
+		Message: input.Comment,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to report profile photo: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Profile photo %d reported.", photo.ID)), nil
+}
+
+// reportMessageMedia reports a media message. Message reports go through a menu of
+// options chosen by Telegram, so each level is answered with the option whose text
+// best matches the requested reason.
+func reportMessageMedia(ctx context.Context, peer tg.InputPeerClass, input reportProfilePhotoInput) (*mcp.CallToolResult, error) {
+	msg, err := getMessageByID(ctx, peer, input.MessageID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get message: %v", err)), nil
+	}
+	if msg.Media == nil {
+		return mcp.NewToolResultError("message has no media to report"), nil
+	}
+
+	keyword := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input.Reason)), "_", " ")
+	var option []byte
+	for range 5 {
+		result, err := services.API().MessagesReport(ctx, &tg.MessagesReportRequest{
+			Peer:    peer,
+			ID:      []int{input.MessageID},
+			Option:  option,
+			Message: input.Comment,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to report message: %v", err)), nil
+		}
+
+		switch r := result.(type) {
+		case *tg.ReportResultReported:
+			return mcp.NewToolResultText(fmt.Sprintf("%s in message %d reported.", mediaTypeName(msg.Media), input.MessageID)), nil
+		case *tg.ReportResultAddComment:
+			option = r.Option
+			if input.Comment == "" && !r.Optional {
+				return mcp.NewToolResultError("Telegram requires a comment for this report; provide comment"), nil
+			}
+		case *tg.ReportResultChooseOption:
+			var texts []string
+			option = nil
+			for _, o := range r.Options {
+				texts = append(texts, o.Text)
+				if option == nil && strings.Contains(strings.ToLower(o.Text), keyword) {
+					option = o.Option
+				}
+			}
+			if option == nil && len(r.Options) > 0 && keyword == "other" {
+				option = r.Options[len(r.Options)-1].Option
+			}
+			if option == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("no report option matches %q (%s: %s)", input.Reason, r.Title, strings.Join(texts, ", "))), nil
+			}
+		default:
+			return mcp.NewToolResultError("unexpected report result"), nil
+		}
+	}
+
+	return mcp.NewToolResultError("report was not completed"), nil
+}
+
+// profilePhotos returns the profile photos of a peer, current photo first.
+func profilePhotos(ctx context.Context, peer tg.InputPeerClass) ([]*tg.Photo, error) {
+	var classes []tg.PhotoClass
+
+	switch p := peer.(type) {
+	case *tg.InputPeerUser:
+		result, err := services.API().PhotosGetUserPhotos(ctx, &tg.PhotosGetUserPhotosRequest{
+			UserID: &tg.InputUser{UserID: p.UserID, AccessHash: p.AccessHash},
+			Limit:  100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		classes = result.GetPhotos()
+	case *tg.InputPeerChannel:
+		result, err := services.API().ChannelsGetFullChannel(ctx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
+		if err != nil {
+			return nil, err
+		}
+		if full, ok := result.FullChat.(*tg.ChannelFull); ok {
+			classes = append(classes, full.ChatPhoto)
+		}
+	case *tg.InputPeerChat:
+		result, err := services.API().MessagesGetFullChat(ctx, p.ChatID)
+		if err != nil {
+			return nil, err
+		}
+		if full, ok := result.FullChat.(*tg.ChatFull); ok {
+			if photo, ok := full.GetChatPhoto(); ok {
+				classes = append(classes, photo)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported peer type")
+	}
+
+	var photos []*tg.Photo
+	for _, pc := range classes {
+		if photo, ok := pc.AsNotEmpty(); ok {
+			photos = append(photos, photo)
+		}
+	}
+	return photos, nil
+}
+
+func handleGetContactsStatuses(_ context.Context, _ mcp.CallToolRequest, input getContactsStatusesInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	statuses, err := services.API().ContactsGetStatuses(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get contact statuses: %v", err)), nil
+	}
+
+	if input.OnlineOnly {
+		online := statuses[:0]
+		for _, st := range statuses {
+			if _, ok := st.Status.(*tg.UserStatusOnline); ok {
+				online = append(online, st)
+			}
+		}
+		statuses = online
+	}
+
+	if len(statuses) == 0 {
+		if input.OnlineOnly {
+			return mcp.NewToolResultText("No contacts online."), nil
+		}
+		return mcp.NewToolResultText("No contact statuses available."), nil
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statusRecency(statuses[i].Status) > statusRecency(statuses[j].Status)
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Contact statuses (%d):\n", len(statuses))
+	for _, st := range statuses {
+		status := formatUserStatus(st.Status)
+		if _, ok := st.Status.(*tg.UserStatusOffline); ok {
+			status = "last seen " + status
+		}
+		if status == "" {
+			status = "hidden"
+		}
+		fmt.Fprintf(&b, "  %s [ID: %d]: %s\n", senderLabel(tgCtx, &tg.PeerUser{UserID: st.UserID}), st.UserID, status)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// statusRecency orders user statuses from most to least recently active.
+func statusRecency(status tg.UserStatusClass) int64 {
+	switch st := status.(type) {
+	case *tg.UserStatusOnline:
+		return math.MaxInt64
+	case *tg.UserStatusOffline:
+		return int64(st.WasOnline)
+	case *tg.UserStatusRecently:
+		return 3
+	case *tg.UserStatusLastWeek:
+		return 2
+	case *tg.UserStatusLastMonth:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func handleGetPeerSettings(_ context.Context, _ mcp.CallToolRequest, input getPeerSettingsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	result, err := services.API().MessagesGetPeerSettings(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get peer settings: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Peer settings for %s:\n", inputPeerLabel(tgCtx, peer))
+	formatPeerSettings(&b, result.Settings)
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// formatPeerSettings lists the action bar hints Telegram shows for a chat.
+func formatPeerSettings(b *strings.Builder, s tg.PeerSettings) {
+	hints := []struct {
+		set   bool
+		label string
+	}{
+		{s.ReportSpam, "Report spam"},
+		{s.BlockContact, "Block user"},
+		{s.AddContact, "Add to contacts"},
+		{s.ShareContact, "Share your phone number"},
+		{s.ReportGeo, "Report irrelevant location"},
+		{s.InviteMembers, "Invite members"},
+	}
+
+	suggested := 0
+	for _, h := range hints {
+		if h.set {
+			fmt.Fprintf(b, "  - %s\n", h.label)
+			suggested++
+		}
+	}
+	if suggested == 0 {
+		b.WriteString("  No suggested actions.\n")
+	}
+
+	if s.Autoarchived {
+		b.WriteString("  Chat was auto-archived as likely unwanted\n")
+	}
+	if distance, ok := s.GetGeoDistance(); ok {
+		fmt.Fprintf(b, "  Distance: %d m\n", distance)
+	}
+	if title, ok := s.GetRequestChatTitle(); ok {
+		fmt.Fprintf(b, "  Joined via join request to: %s\n", title)
+	}
+	if month, ok := s.GetRegistrationMonth(); ok {
+		fmt.Fprintf(b, "  Account registered: %s\n", month)
+	}
+	if country, ok := s.GetPhoneCountry(); ok {
+		fmt.Fprintf(b, "  Phone country: %s\n", country)
+	}
+}
@@ -25,6 +25,32 @@ type deleteFolderInput struct {
 	ID int `json:"id" jsonschema:"required"`
 }
 
+type searchInFolderInput struct {
+	FolderID     int    `json:"folder_id" jsonschema:"required"`
+	Query        string `json:"query" jsonschema:"required"`
+	LimitPerChat int    `json:"limit_per_chat"`
+}
+
+type getSuggestedFoldersInput struct{}
+
+type exportFolderLinkInput struct {
+	FolderID int    `json:"folder_id" jsonschema:"required"`
+	Title    string `json:"title"`
+	Peers    string `json:"peers"`
+}
+
+type joinFolderLinkInput struct {
+	Link string `json:"link" jsonschema:"required"`
+}
+
+type getFolderLinkUpdatesInput struct {
+	FolderID int `json:"folder_id" jsonschema:"required"`
+}
+
+type joinFolderLinkUpdatesInput struct {
+	FolderID int `json:"folder_id" jsonschema:"required"`
+}
+
 func RegisterFolderTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_get_folders",
@@ -58,6 +84,69 @@ func RegisterFolderTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleDeleteFolder),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_search_in_folder",
+			mcp.WithDescription("Search messages in every chat explicitly included in a dialog folder, grouped by chat"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("folder_id", mcp.Required(), mcp.Description("Folder ID (see telegram_get_folders)")),
+			mcp.WithString("query", mcp.Required(), mcp.Description("Search query string")),
+			mcp.WithNumber("limit_per_chat", mcp.Description("Maximum results per chat (default 10)")),
+		),
+		mcp.NewTypedToolHandler(handleSearchInFolder),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_suggested_folders",
+			mcp.WithDescription("Get folders Telegram suggests creating (e.g. Unread, Personal) with their rules"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetSuggestedFolders),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_export_folder_link",
+			mcp.WithDescription("Share a folder as a t.me/addlist link others can use to join its groups and channels. A regular folder becomes a shareable chatlist"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("folder_id", mcp.Required(), mcp.Description("Folder ID (see telegram_get_folders)")),
+			mcp.WithString("title", mcp.Description("Link name, visible only to you")),
+			mcp.WithString("peers", mcp.Description("Comma-separated groups/channels (IDs or @usernames) to share (default: all groups and channels in the folder)")),
+		),
+		mcp.NewTypedToolHandler(handleExportFolderLink),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_join_folder_link",
+			mcp.WithDescription("Join a shared folder from a t.me/addlist link, adding the folder and joining all of its chats"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("link", mcp.Required(), mcp.Description("Folder link (https://t.me/addlist/...) or its slug")),
+		),
+		mcp.NewTypedToolHandler(handleJoinFolderLink),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_folder_link_updates",
+			mcp.WithDescription("List chats added to a joined shared folder since you joined it"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("folder_id", mcp.Required(), mcp.Description("ID of a folder joined from a link (see telegram_get_folders)")),
+		),
+		mcp.NewTypedToolHandler(handleGetFolderLinkUpdates),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_join_folder_link_updates",
+			mcp.WithDescription("Join the chats added to a joined shared folder since you joined it, adding them to the folder"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("folder_id", mcp.Required(), mcp.Description("ID of a folder joined from a link (see telegram_get_folders)")),
+		),
+		mcp.NewTypedToolHandler(handleJoinFolderLinkUpdates),
+	)
 }
 
 func handleGetFolders(_ context.Context, _ mcp.CallToolRequest, _ getFoldersInput) (*mcp.CallToolResult, error) {
@@ -141,6 +230,348 @@ func handleDeleteFolder(_ context.Context, _ mcp.CallToolRequest, input deleteFo
 	return mcp.NewToolResultText(fmt.Sprintf("Folder ID %d deleted successfully.", input.ID)), nil
 }
 
+func handleGetSuggestedFolders(_ context.Context, _ mcp.CallToolRequest, _ getSuggestedFoldersInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	suggested, err := services.API().MessagesGetSuggestedDialogFilters(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get suggested folders: %v", err)), nil
+	}
+
+	if len(suggested) == 0 {
+		return mcp.NewToolResultText("No suggested folders."), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Suggested folders (%d):\n", len(suggested))
+
+	for i, sf := range suggested {
+		f, ok := sf.Filter.(*tg.DialogFilter)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%d. %s", i+1, f.Title.Text)
+		if sf.Description != "" {
+			fmt.Fprintf(&b, " - %s", sf.Description)
+		}
+		if rules := folderRules(f); rules != "" {
+			fmt.Fprintf(&b, "\n   Rules: %s", rules)
+		}
+		b.WriteString("\n")
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// folderRules describes the chat-type and exclusion flags of a folder.
+func folderRules(f *tg.DialogFilter) string {
+	var rules []string
+	for _, r := range []struct {
+		set  bool
+		name string
+	}{
+		{f.Contacts, "contacts"},
+		{f.NonContacts, "non-contacts"},
+		{f.Groups, "groups"},
+		{f.Broadcasts, "channels"},
+		{f.Bots, "bots"},
+		{f.ExcludeMuted, "exclude muted"},
+		{f.ExcludeRead, "exclude read"},
+		{f.ExcludeArchived, "exclude archived"},
+	} {
+		if r.set {
+			rules = append(rules, r.name)
+		}
+	}
+	return strings.Join(rules, ", ")
+}
+
+func handleSearchInFolder(_ context.Context, _ mcp.CallToolRequest, input searchInFolderInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limitPerChat := input.LimitPerChat
+	if limitPerChat <= 0 {
+		limitPerChat = 10
+	}
+	if limitPerChat > 100 {
+		limitPerChat = 100
+	}
+
+	peers, title, err := folderPeers(tgCtx, input.FolderID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(peers) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Folder %q has no explicitly included chats to search.", title)), nil
+	}
+
+	const maxPeers = 50
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Search for %q in folder %q across %d chat(s):\n", input.Query, title, len(peers))
+	if len(peers) > maxPeers {
+		fmt.Fprintf(&sb, "(only the first %d chats are searched)\n", maxPeers)
+		peers = peers[:maxPeers]
+	}
+
+	totalResults := 0
+	for _, peer := range peers {
+		label := inputPeerLabel(tgCtx, peer)
+
+		result, err := services.API().MessagesSearch(tgCtx, &tg.MessagesSearchRequest{
+			Peer:   peer,
+			Q:      input.Query,
+			Filter: &tg.InputMessagesFilterEmpty{},
+			Limit:  limitPerChat,
+		})
+		if err != nil {
+			fmt.Fprintf(&sb, "\n--- %s ---\n", label)
+			fmt.Fprintf(&sb, "  Search failed: %v\n", err)
+			continue
+		}
+
+		msgs := extractMessages(tgCtx, result)
+		if len(msgs) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n--- %s (%d results) ---\n", label, len(msgs))
+		sb.WriteString(formatMessages(tgCtx, msgs))
+		totalResults += len(msgs)
+	}
+
+	fmt.Fprintf(&sb, "\nTotal results: %d\n", totalResults)
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleExportFolderLink(_ context.Context, _ mcp.CallToolRequest, input exportFolderLinkInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	var (
+		peers []tg.InputPeerClass
+		err   error
+	)
+	if input.Peers != "" {
+		peers, err = resolvePeerList(tgCtx, input.Peers)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peers: %v", err)), nil
+		}
+	} else {
+		all, _, err := folderPeers(tgCtx, input.FolderID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// Only groups and channels can be shared in a folder link
+		for _, peer := range all {
+			switch peer.(type) {
+			case *tg.InputPeerChannel, *tg.InputPeerChat:
+				peers = append(peers, peer)
+			}
+		}
+	}
+	if len(peers) == 0 {
+		return mcp.NewToolResultError("folder has no groups or channels to share"), nil
+	}
+
+	result, err := services.API().ChatlistsExportChatlistInvite(tgCtx, &tg.ChatlistsExportChatlistInviteRequest{
+		Chatlist: tg.InputChatlistDialogFilter{FilterID: input.FolderID},
+		Title:    input.Title,
+		Peers:    peers,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to export folder link: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Folder link: %s (%d chats)", result.Invite.URL, len(result.Invite.Peers))), nil
+}
+
+func handleJoinFolderLink(_ context.Context, _ mcp.CallToolRequest, input joinFolderLinkInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	slug := chatlistSlugFromLink(input.Link)
+	if slug == "" {
+		return mcp.NewToolResultError("invalid folder link"), nil
+	}
+
+	invite, err := services.API().ChatlistsCheckChatlistInvite(tgCtx, slug)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to check folder link: %v", err)), nil
+	}
+
+	var (
+		title   string
+		missing []tg.PeerClass
+	)
+	switch v := invite.(type) {
+	case *tg.ChatlistsChatlistInvite:
+		services.StorePeers(tgCtx, v.Chats, v.Users)
+		title = v.Title.Text
+		missing = v.Peers
+	case *tg.ChatlistsChatlistInviteAlready:
+		services.StorePeers(tgCtx, v.Chats, v.Users)
+		missing = v.MissingPeers
+		if len(missing) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("Already joined this folder (folder ID: %d).", v.FilterID)), nil
+		}
+	}
+
+	peers := make([]tg.InputPeerClass, 0, len(missing))
+	for _, p := range missing {
+		peer, err := services.GetInputPeerByID(tgCtx, peerToID(p))
+		if err != nil {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+
+	if _, err := services.API().ChatlistsJoinChatlistInvite(tgCtx, &tg.ChatlistsJoinChatlistInviteRequest{
+		Slug:  slug,
+		Peers: peers,
+	}); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to join folder: %v", err)), nil
+	}
+
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "Joined folder %q", title)
+	} else {
+		b.WriteString("Joined folder")
+	}
+	fmt.Fprintf(&b, " (%d chats):\n", len(peers))
+	for _, peer := range peers {
+		fmt.Fprintf(&b, "  - %s\n", inputPeerLabel(tgCtx, peer))
+	}
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetFolderLinkUpdates(_ context.Context, _ mcp.CallToolRequest, input getFolderLinkUpdatesInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	chatlist := tg.InputChatlistDialogFilter{FilterID: input.FolderID}
+	result, err := services.API().ChatlistsGetChatlistUpdates(tgCtx, chatlist)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get folder updates: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	if len(result.MissingPeers) == 0 {
+		return mcp.NewToolResultText("No new chats in this folder."), nil
+	}
+
+	chats := make(map[int64]tg.ChatClass, len(result.Chats))
+	for _, c := range result.Chats {
+		chats[c.GetID()] = c
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "New chats in folder %d (%d):\n", input.FolderID, len(result.MissingPeers))
+	for _, p := range result.MissingPeers {
+		b.WriteString("\n")
+		if c, ok := chats[peerToID(p)]; ok {
+			formatChat(&b, c)
+		} else {
+			fmt.Fprintf(&b, "%s\n", peerDisplayName(tgCtx, p))
+		}
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleJoinFolderLinkUpdates(_ context.Context, _ mcp.CallToolRequest, input joinFolderLinkUpdatesInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	chatlist := tg.InputChatlistDialogFilter{FilterID: input.FolderID}
+	result, err := services.API().ChatlistsGetChatlistUpdates(tgCtx, chatlist)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get folder updates: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	if len(result.MissingPeers) == 0 {
+		return mcp.NewToolResultText("No new chats in this folder."), nil
+	}
+
+	peers := make([]tg.InputPeerClass, 0, len(result.MissingPeers))
+	for _, p := range result.MissingPeers {
+		if peer, err := services.GetInputPeerByID(tgCtx, peerToID(p)); err == nil {
+			peers = append(peers, peer)
+		}
+	}
+
+	if _, err := services.API().ChatlistsJoinChatlistUpdates(tgCtx, &tg.ChatlistsJoinChatlistUpdatesRequest{
+		Chatlist: chatlist,
+		Peers:    peers,
+	}); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to join new folder chats: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Joined %d new chats in folder %d.", len(peers), input.FolderID)), nil
+}
+
+// chatlistSlugFromLink extracts the slug from a t.me/addlist link, accepting a bare slug too.
+func chatlistSlugFromLink(link string) string {
+	link = strings.TrimSpace(link)
+	for _, prefix := range []string{"https://t.me/addlist/", "http://t.me/addlist/", "t.me/addlist/", "tg://addlist?slug="} {
+		if strings.HasPrefix(link, prefix) {
+			return strings.TrimPrefix(link, prefix)
+		}
+	}
+	if strings.Contains(link, "/") {
+		return ""
+	}
+	return link
+}
+
+// folderPeers returns the pinned and included peers of a dialog folder along with its title.
+func folderPeers(ctx context.Context, folderID int) ([]tg.InputPeerClass, string, error) {
+	result, err := services.API().MessagesGetDialogFilters(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get folders: %v", err)
+	}
+
+	for _, fc := range result.Filters {
+		switch f := fc.(type) {
+		case *tg.DialogFilter:
+			if f.ID == folderID {
+				return append(append([]tg.InputPeerClass{}, f.PinnedPeers...), f.IncludePeers...), f.Title.Text, nil
+			}
+		case *tg.DialogFilterChatlist:
+			if f.ID == folderID {
+				return append(append([]tg.InputPeerClass{}, f.PinnedPeers...), f.IncludePeers...), f.Title.Text, nil
+			}
+		}
+	}
+
+	return nil, "", fmt.Errorf("folder %d not found", folderID)
+}
+
+// inputPeerLabel returns a display name for an input peer, falling back to its ID.
+func inputPeerLabel(ctx context.Context, peer tg.InputPeerClass) string {
+	if _, ok := peer.(*tg.InputPeerSelf); ok {
+		return "Saved Messages"
+	}
+	p := inputPeerToPeer(peer)
+	if p == nil {
+		return fmt.Sprintf("%T", peer)
+	}
+	return senderLabel(ctx, p)
+}
+
+// inputPeerToPeer converts an input peer to its plain peer form, or nil if it has none.
+func inputPeerToPeer(peer tg.InputPeerClass) tg.PeerClass {
+	switch v := peer.(type) {
+	case *tg.InputPeerUser:
+		return &tg.PeerUser{UserID: v.UserID}
+	case *tg.InputPeerChat:
+		return &tg.PeerChat{ChatID: v.ChatID}
+	case *tg.InputPeerChannel:
+		return &tg.PeerChannel{ChannelID: v.ChannelID}
+	default:
+		return nil
+	}
+}
+
 func resolvePeerList(ctx context.Context, commaSeparated string) ([]tg.InputPeerClass, error) {
 	if commaSeparated == "" {
 		return nil, nil
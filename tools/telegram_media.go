@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"github.com/gotd/td/telegram/downloader"
 	"github.com/gotd/td/telegram/uploader"
 	"github.com/gotd/td/tg"
+	"github.com/gotd/td/tgerr"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/nguyenvanduocit/telegram-mcp/services"
@@ -23,12 +27,30 @@ type downloadMediaInput struct {
 	Peer        string `json:"peer" jsonschema:"required"`
 	MessageID   int    `json:"message_id" jsonschema:"required"`
 	DownloadDir string `json:"download_dir"`
+	Threads     int    `json:"threads"`
+	Inline      bool   `json:"inline"`
 }
 
 type sendMediaInput struct {
 	Peer     string `json:"peer" jsonschema:"required"`
 	FilePath string `json:"file_path" jsonschema:"required"`
 	Caption  string `json:"caption"`
+	Threads  int    `json:"threads"`
+	TopicID  int    `json:"topic_id"`
+}
+
+// maxInlineDownloadSize caps files returned base64-encoded in the tool result.
+const maxInlineDownloadSize = 2 * 1024 * 1024
+
+// maxTransferThreads caps parallel parts per transfer; more only adds flood waits.
+const maxTransferThreads = 8
+
+// transferThreads returns the parallel parts to use, falling back to TELEGRAM_TRANSFER_THREADS.
+func transferThreads(requested int) int {
+	if requested <= 0 {
+		requested = services.TransferThreads()
+	}
+	return min(requested, maxTransferThreads)
 }
 
 type getFileInfoInput struct {
@@ -41,6 +63,12 @@ type viewImageInput struct {
 	MessageID int    `json:"message_id" jsonschema:"required"`
 }
 
+type getChatPhotoInput struct {
+	Peer        string `json:"peer" jsonschema:"required"`
+	DownloadDir string `json:"download_dir"`
+	Big         bool   `json:"big"`
+}
+
 var photoSizeOrder = map[string]int{
 	"s": 1, "m": 2, "x": 3, "y": 4, "w": 5,
 }
@@ -54,6 +82,8 @@ func RegisterMediaTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message containing media")),
 			mcp.WithString("download_dir", mcp.Description("Directory to save the file (default ./downloads)")),
+			mcp.WithNumber("threads", mcp.Description("Parts to download in parallel for large files (default TELEGRAM_TRANSFER_THREADS or 1, max 8)")),
+			mcp.WithBoolean("inline", mcp.Description("Return the file content in the result instead of saving it, for files up to 2 MB (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleDownloadMedia),
 	)
@@ -66,6 +96,8 @@ func RegisterMediaTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithString("file_path", mcp.Required(), mcp.Description("Path to the file to send")),
 			mcp.WithString("caption", mcp.Description("Caption for the media (optional)")),
+			mcp.WithNumber("threads", mcp.Description("Parts to upload in parallel for large files (default TELEGRAM_TRANSFER_THREADS or 1, max 8)")),
+			mcp.WithNumber("topic_id", mcp.Description("Forum topic ID to post into (supergroups with topics enabled)")),
 		),
 		mcp.NewTypedToolHandler(handleSendMedia),
 	)
@@ -91,12 +123,27 @@ func RegisterMediaTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleViewImage),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_chat_photo",
+			mcp.WithDescription("Download the current profile photo of a user, group or channel"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("User, group or channel ID or @username")),
+			mcp.WithString("download_dir", mcp.Description("Directory to save the photo (default ./downloads)")),
+			mcp.WithBoolean("big", mcp.Description("Download the full-size photo instead of the 160x160 thumbnail (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleGetChatPhoto),
+	)
 }
 
-// Helper: get a single message by ID, handling both channel and non-channel peers
+// Helper: get messages by ID, handling both channel and non-channel peers
 
-func getMessageByID(ctx context.Context, peer tg.InputPeerClass, msgID int) (*tg.Message, error) {
-	ids := []tg.InputMessageClass{&tg.InputMessageID{ID: msgID}}
+func getMessagesByIDs(ctx context.Context, peer tg.InputPeerClass, msgIDs []int) ([]tg.MessageClass, error) {
+	ids := make([]tg.InputMessageClass, len(msgIDs))
+	for i, id := range msgIDs {
+		ids[i] = &tg.InputMessageID{ID: id}
+	}
 
 	var result tg.MessagesMessagesClass
 	var err error
@@ -114,10 +161,20 @@ func getMessageByID(ctx context.Context, peer tg.InputPeerClass, msgID int) (*tg
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("get message: %w", err)
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+
+	return extractMessages(ctx, result), nil
+}
+
+// Helper: get a single message by ID
+
+func getMessageByID(ctx context.Context, peer tg.InputPeerClass, msgID int) (*tg.Message, error) {
+	msgs, err := getMessagesByIDs(ctx, peer, []int{msgID})
+	if err != nil {
+		return nil, err
 	}
 
-	msgs := extractMessages(ctx, result)
 	if len(msgs) == 0 {
 		return nil, fmt.Errorf("message %d not found", msgID)
 	}
@@ -130,7 +187,57 @@ func getMessageByID(ctx context.Context, peer tg.InputPeerClass, msgID int) (*tg
 	return msg, nil
 }
 
-// Helper: detect MIME type from file extension
+// downloadWithFileRefresh runs download and, if Telegram rejects the file
+// reference as expired, re-fetches the message to get a fresh one and retries once.
+func downloadWithFileRefresh(ctx context.Context, peer tg.InputPeerClass, msgID int, loc tg.InputFileLocationClass, download func(tg.InputFileLocationClass) error) error {
+	err := download(loc)
+	if err == nil || !tgerr.Is(err, "FILE_REFERENCE_EXPIRED") {
+		return err
+	}
+
+	msg, fetchErr := getMessageByID(ctx, peer, msgID)
+	if fetchErr != nil {
+		return fmt.Errorf("%w (refreshing file reference failed: %v)", err, fetchErr)
+	}
+	fresh, ok := refreshFileLocation(loc, msg.Media)
+	if !ok {
+		return err
+	}
+	return download(fresh)
+}
+
+// refreshFileLocation copies loc with the file reference taken from the re-fetched media
+func refreshFileLocation(loc tg.InputFileLocationClass, media tg.MessageMediaClass) (tg.InputFileLocationClass, bool) {
+	switch l := loc.(type) {
+	case *tg.InputPhotoFileLocation:
+		m, ok := media.(*tg.MessageMediaPhoto)
+		if !ok {
+			return nil, false
+		}
+		photo, ok := m.Photo.(*tg.Photo)
+		if !ok || photo.ID != l.ID {
+			return nil, false
+		}
+		fresh := *l
+		fresh.FileReference = photo.FileReference
+		return &fresh, true
+	case *tg.InputDocumentFileLocation:
+		m, ok := media.(*tg.MessageMediaDocument)
+		if !ok {
+			return nil, false
+		}
+		doc, ok := m.Document.(*tg.Document)
+		if !ok || doc.ID != l.ID {
+			return nil, false
+		}
+		fresh := *l
+		fresh.FileReference = doc.FileReference
+		return &fresh, true
+	}
+	return nil, false
+}
+
+// Helper: detect MIME type from file extension, falling back to the file content
 
 func mimeFromPath(path string) string {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -158,8 +265,76 @@ func mimeFromPath(path string) string {
 	case ".txt":
 		return "text/plain"
 	default:
+		return mimeFromContent(path)
+	}
+}
+
+// mimeFromContent sniffs the MIME type from the first 512 bytes of the file
+func mimeFromContent(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && n == 0 {
 		return "application/octet-stream"
 	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+	return mimeType
+}
+
+// Helper: short media type name for tabular output
+
+func mediaTypeName(media tg.MessageMediaClass) string {
+	switch m := media.(type) {
+	case nil:
+		return ""
+	case *tg.MessageMediaPhoto:
+		return "photo"
+	case *tg.MessageMediaDocument:
+		doc, ok := m.Document.(*tg.Document)
+		if !ok {
+			return "document"
+		}
+		for _, attr := range doc.Attributes {
+			switch a := attr.(type) {
+			case *tg.DocumentAttributeSticker:
+				return "sticker"
+			case *tg.DocumentAttributeVideo:
+				if a.RoundMessage {
+					return "video_note"
+				}
+				return "video"
+			case *tg.DocumentAttributeAudio:
+				if a.Voice {
+					return "voice"
+				}
+				return "audio"
+			case *tg.DocumentAttributeAnimated:
+				return "animation"
+			}
+		}
+		return "document"
+	case *tg.MessageMediaWebPage:
+		return "webpage"
+	case *tg.MessageMediaGeo, *tg.MessageMediaGeoLive, *tg.MessageMediaVenue:
+		return "location"
+	case *tg.MessageMediaContact:
+		return "contact"
+	case *tg.MessageMediaPoll:
+		return "poll"
+	case *tg.MessageMediaDice:
+		return "dice"
+	case *tg.MessageMediaStory:
+		return "story"
+	case *tg.MessageMediaEmpty:
+		return ""
+	default:
+		return "other"
+	}
 }
 
 func handleDownloadMedia(_ context.Context, _ mcp.CallToolRequest, input downloadMediaInput) (*mcp.CallToolResult, error) {
@@ -179,21 +354,13 @@ func handleDownloadMedia(_ context.Context, _ mcp.CallToolRequest, input downloa
 		return mcp.NewToolResultError("message has no media"), nil
 	}
 
-	downloadDir := input.DownloadDir
-	if downloadDir == "" {
-		downloadDir = "./downloads"
-	}
-	downloadDir = filepath.Clean(downloadDir)
-	absDir, err := filepath.Abs(downloadDir)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("invalid download_dir: %v", err)), nil
-	}
-	downloadDir = absDir
-	if err := os.MkdirAll(downloadDir, 0700); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to create download dir: %v", err)), nil
-	}
-
-	d := downloader.NewDownloader()
+	var (
+		loc      tg.InputFileLocationClass
+		filename string
+		mimeType string
+		size     int64
+		kind     string
+	)
 
 	switch media := msg.Media.(type) {
 	case *tg.MessageMediaPhoto:
@@ -214,20 +381,15 @@ func handleDownloadMedia(_ context.Context, _ mcp.CallToolRequest, input downloa
 			return mcp.NewToolResultError("no photo sizes available"), nil
 		}
 
-		loc := &tg.InputPhotoFileLocation{
+		loc = &tg.InputPhotoFileLocation{
 			ID:            photo.ID,
 			AccessHash:    photo.AccessHash,
 			FileReference: photo.FileReference,
 			ThumbSize:     bestType,
 		}
-
-		filePath := filepath.Join(downloadDir, fmt.Sprintf("photo_%d_%d.jpg", msg.ID, photo.ID))
-		_, err = d.Download(services.API(), loc).ToPath(tgCtx, filePath)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to download photo: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(fmt.Sprintf("Photo downloaded to: %s", filePath)), nil
+		filename = fmt.Sprintf("photo_%d_%d.jpg", msg.ID, photo.ID)
+		mimeType = "image/jpeg"
+		kind = "Photo"
 
 	case *tg.MessageMediaDocument:
 		doc, ok := media.Document.(*tg.Document)
@@ -236,7 +398,7 @@ func handleDownloadMedia(_ context.Context, _ mcp.CallToolRequest, input downloa
 		}
 
 		// Determine filename from attributes
-		filename := fmt.Sprintf("doc_%d_%d", msg.ID, doc.ID)
+		filename = fmt.Sprintf("doc_%d_%d", msg.ID, doc.ID)
 		for _, attr := range doc.Attributes {
 			if fn, ok := attr.(*tg.DocumentAttributeFilename); ok {
 				filename = filepath.Base(fn.FileName)
@@ -244,23 +406,76 @@ func handleDownloadMedia(_ context.Context, _ mcp.CallToolRequest, input downloa
 			}
 		}
 
-		loc := &tg.InputDocumentFileLocation{
+		loc = &tg.InputDocumentFileLocation{
 			ID:            doc.ID,
 			AccessHash:    doc.AccessHash,
 			FileReference: doc.FileReference,
 		}
+		mimeType = doc.MimeType
+		size = doc.Size
+		kind = "Document"
+
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported media type: %T", msg.Media)), nil
+	}
+
+	d := downloader.NewDownloader()
+	threads := transferThreads(input.Threads)
 
-		filePath := filepath.Join(downloadDir, filename)
-		_, err = d.Download(services.API(), loc).ToPath(tgCtx, filePath)
+	if input.Inline {
+		if size > maxInlineDownloadSize {
+			return mcp.NewToolResultError(fmt.Sprintf("file is %s, too large to return inline (max %s); download it to disk instead", formatSize(size), formatSize(maxInlineDownloadSize))), nil
+		}
+
+		var buf bytes.Buffer
+		err := downloadWithFileRefresh(tgCtx, peer, msg.ID, loc, func(loc tg.InputFileLocationClass) error {
+			buf.Reset()
+			_, err := d.Download(services.API(), loc).WithThreads(threads).Stream(tgCtx, &buf)
+			return err
+		})
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to download document: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to download %s: %v", strings.ToLower(kind), err)), nil
+		}
+		if int64(buf.Len()) > maxInlineDownloadSize {
+			return mcp.NewToolResultError(fmt.Sprintf("file is %s, too large to return inline (max %s); download it to disk instead", formatSize(int64(buf.Len())), formatSize(maxInlineDownloadSize))), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Document downloaded to: %s", filePath)), nil
+		b64 := base64.StdEncoding.EncodeToString(buf.Bytes())
+		text := fmt.Sprintf("%s from message %d: %s (%s)", kind, msg.ID, filename, formatSize(int64(buf.Len())))
+		if strings.HasPrefix(mimeType, "image/") {
+			return mcp.NewToolResultImage(text, b64, mimeType), nil
+		}
+		return mcp.NewToolResultResource(text, mcp.BlobResourceContents{
+			URI:      fmt.Sprintf("telegram://message/%d/%s", msg.ID, url.PathEscape(filename)),
+			MIMEType: mimeType,
+			Blob:     b64,
+		}), nil
+	}
 
-	default:
-		return mcp.NewToolResultError(fmt.Sprintf("unsupported media type: %T", msg.Media)), nil
+	downloadDir := input.DownloadDir
+	if downloadDir == "" {
+		downloadDir = "./downloads"
+	}
+	downloadDir = filepath.Clean(downloadDir)
+	absDir, err := filepath.Abs(downloadDir)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid download_dir: %v", err)), nil
+	}
+	downloadDir = absDir
+	if err := os.MkdirAll(downloadDir, 0700); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create download dir: %v", err)), nil
 	}
+
+	filePath := filepath.Join(downloadDir, filename)
+	err = downloadWithFileRefresh(tgCtx, peer, msg.ID, loc, func(loc tg.InputFileLocationClass) error {
+		_, err := d.Download(services.API(), loc).WithThreads(threads).ToPath(tgCtx, filePath)
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download %s: %v", strings.ToLower(kind), err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("%s downloaded to: %s", kind, filePath)), nil
 }
 
 func handleSendMedia(_ context.Context, _ mcp.CallToolRequest, input sendMediaInput) (*mcp.CallToolResult, error) {
@@ -271,6 +486,11 @@ func handleSendMedia(_ context.Context, _ mcp.CallToolRequest, input sendMediaIn
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
+	replyTo, err := messageReplyTo(peer, 0, input.TopicID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	cleanPath := filepath.Clean(input.FilePath)
 	if !filepath.IsAbs(cleanPath) {
 		return mcp.NewToolResultError("file_path must be an absolute path"), nil
@@ -279,7 +499,7 @@ func handleSendMedia(_ context.Context, _ mcp.CallToolRequest, input sendMediaIn
 		return mcp.NewToolResultError(fmt.Sprintf("file not found: %v", err)), nil
 	}
 
-	u := uploader.NewUploader(services.API())
+	u := uploader.NewUploader(services.API()).WithThreads(transferThreads(input.Threads))
 	uploaded, err := u.FromPath(tgCtx, cleanPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to upload file: %v", err)), nil
@@ -287,7 +507,7 @@ func handleSendMedia(_ context.Context, _ mcp.CallToolRequest, input sendMediaIn
 
 	mimeType := mimeFromPath(cleanPath)
 
-	_, err = services.API().MessagesSendMedia(tgCtx, &tg.MessagesSendMediaRequest{
+	sendReq := &tg.MessagesSendMediaRequest{
 		Peer: peer,
 		Media: &tg.InputMediaUploadedDocument{
 			File:     uploaded,
@@ -298,6 +518,14 @@ func handleSendMedia(_ context.Context, _ mcp.CallToolRequest, input sendMediaIn
 		},
 		Message:  input.Caption,
 		RandomID: randomID(),
+	}
+	if replyTo != nil {
+		sendReq.SetReplyTo(replyTo)
+	}
+
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		_, err := services.API().MessagesSendMedia(ctx, sendReq)
+		return err
 	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to send media: %v", err)), nil
@@ -453,7 +681,12 @@ func handleViewImage(_ context.Context, _ mcp.CallToolRequest, input viewImageIn
 
 	var buf bytes.Buffer
 	d := downloader.NewDownloader()
-	if _, err := d.Download(services.API(), loc).Stream(tgCtx, &buf); err != nil {
+	err = downloadWithFileRefresh(tgCtx, peer, msg.ID, loc, func(loc tg.InputFileLocationClass) error {
+		buf.Reset()
+		_, err := d.Download(services.API(), loc).Stream(tgCtx, &buf)
+		return err
+	})
+	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to download photo: %v", err)), nil
 	}
 
@@ -461,6 +694,89 @@ func handleViewImage(_ context.Context, _ mcp.CallToolRequest, input viewImageIn
 	return mcp.NewToolResultImage(fmt.Sprintf("Photo from message %d", msg.ID), b64, detectImageMIME(buf.Bytes())), nil
 }
 
+// currentChatPhoto returns the current profile photo from the peer's full info, or nil if it has none
+func currentChatPhoto(ctx context.Context, peer tg.InputPeerClass) (*tg.Photo, error) {
+	var photo tg.PhotoClass
+	switch p := peer.(type) {
+	case *tg.InputPeerUser, *tg.InputPeerSelf:
+		var inputUser tg.InputUserClass = &tg.InputUserSelf{}
+		if u, ok := p.(*tg.InputPeerUser); ok {
+			inputUser = &tg.InputUser{UserID: u.UserID, AccessHash: u.AccessHash}
+		}
+		result, err := services.API().UsersGetFullUser(ctx, inputUser)
+		if err != nil {
+			return nil, err
+		}
+		services.StorePeers(ctx, result.Chats, result.Users)
+		photo, _ = result.FullUser.GetProfilePhoto()
+	case *tg.InputPeerChannel:
+		result, err := services.API().ChannelsGetFullChannel(ctx, &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash})
+		if err != nil {
+			return nil, err
+		}
+		services.StorePeers(ctx, result.Chats, result.Users)
+		if full, ok := result.FullChat.(*tg.ChannelFull); ok {
+			photo = full.ChatPhoto
+		}
+	case *tg.InputPeerChat:
+		result, err := services.API().MessagesGetFullChat(ctx, p.ChatID)
+		if err != nil {
+			return nil, err
+		}
+		services.StorePeers(ctx, result.Chats, result.Users)
+		if full, ok := result.FullChat.(*tg.ChatFull); ok {
+			photo, _ = full.GetChatPhoto()
+		}
+	default:
+		return nil, fmt.Errorf("unsupported peer type")
+	}
+
+	if photo == nil {
+		return nil, nil
+	}
+	p, _ := photo.AsNotEmpty()
+	return p, nil
+}
+
+func handleGetChatPhoto(_ context.Context, _ mcp.CallToolRequest, input getChatPhotoInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	photo, err := currentChatPhoto(tgCtx, peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+	}
+	if photo == nil {
+		return mcp.NewToolResultText("This chat has no profile photo."), nil
+	}
+
+	absDir, err := prepareOutputDir(input.DownloadDir)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	size := "small"
+	if input.Big {
+		size = "big"
+	}
+	filePath := filepath.Join(absDir, fmt.Sprintf("chat_photo_%d_%s.jpg", photo.ID, size))
+
+	loc := &tg.InputPeerPhotoFileLocation{
+		Peer:    peer,
+		PhotoID: photo.ID,
+		Big:     input.Big,
+	}
+	if _, err := downloader.NewDownloader().Download(services.API(), loc).ToPath(tgCtx, filePath); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download photo: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Profile photo downloaded to: %s", filePath)), nil
+}
+
 func detectImageMIME(data []byte) string {
 	if len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF {
 		return "image/jpeg"
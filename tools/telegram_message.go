@@ -5,10 +5,15 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
+	"github.com/gotd/contrib/storage"
 	"github.com/gotd/td/tg"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -27,11 +32,19 @@ func randomID() int64 {
 	return v
 }
 
-func formatMessages(msgs []tg.MessageClass) string {
+func formatMessages(ctx context.Context, msgs []tg.MessageClass) string {
+	return formatMessageList(ctx, msgs, false)
+}
+
+// formatMessageList formats messages one per line. With markdown set, formatting
+// entities such as bold, code and text links are rendered into the message text.
+func formatMessageList(ctx context.Context, msgs []tg.MessageClass, markdown bool) string {
 	if len(msgs) == 0 {
 		return "No messages found."
 	}
 
+	names := make(map[senderKey]string)
+
 	var sb strings.Builder
 	for _, mc := range msgs {
 		msg, ok := mc.(*tg.Message)
@@ -41,24 +54,103 @@ func formatMessages(msgs []tg.MessageClass) string {
 
 		t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
 
-		var senderID int64
-		if msg.FromID != nil {
-			switch p := msg.FromID.(type) {
-			case *tg.PeerUser:
-				senderID = p.UserID
-			case *tg.PeerChat:
-				senderID = p.ChatID
-			case *tg.PeerChannel:
-				senderID = p.ChannelID
-			}
+		sender := cachedSenderLabel(ctx, names, msg)
+
+		// Signed channel posts and anonymous admin messages carry the author's signature
+		if author, ok := msg.GetPostAuthor(); ok {
+			sender += fmt.Sprintf(" [author: %s]", author)
+		}
+
+		text := msg.Message
+		if markdown && len(msg.Entities) > 0 {
+			text = renderEntities(text, msg.Entities)
 		}
 
-		fmt.Fprintf(&sb, "[%d] %d (%s): %s\n", msg.ID, senderID, t, msg.Message)
+		fmt.Fprintf(&sb, "[%d] %s (%s): %s", msg.ID, sender, t, text)
+		if msg.EditDate != 0 && !msg.EditHide {
+			fmt.Fprintf(&sb, " (edited at %s)", time.Unix(int64(msg.EditDate), 0).UTC().Format("2006-01-02 15:04:05"))
+		}
+		// Views and forwards are only set on channel posts
+		if views, ok := msg.GetViews(); ok {
+			fmt.Fprintf(&sb, " [views: %d", views)
+			if forwards, ok := msg.GetForwards(); ok {
+				fmt.Fprintf(&sb, ", forwards: %d", forwards)
+			}
+			sb.WriteString("]")
+		}
+		sb.WriteString("\n")
 	}
 
 	return sb.String()
 }
 
+// peerDisplayName returns a human-readable name for a peer previously persisted
+// by StorePeers, or an empty string if the peer is unknown.
+func peerDisplayName(ctx context.Context, p tg.PeerClass) string {
+	if p == nil {
+		return ""
+	}
+	stored, err := storage.FindPeer(ctx, services.PeerStorage(), p)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case stored.User != nil:
+		name := stored.User.FirstName
+		if stored.User.LastName != "" {
+			name += " " + stored.User.LastName
+		}
+		if stored.User.Username != "" {
+			name += fmt.Sprintf(" (@%s)", stored.User.Username)
+		}
+		return name
+	case stored.Channel != nil:
+		return stored.Channel.Title
+	case stored.Chat != nil:
+		return stored.Chat.Title
+	default:
+		return ""
+	}
+}
+
+// messageSender returns who sent msg. Incoming private messages and channel posts
+// have no FromID; the sender is then the chat itself.
+func messageSender(msg *tg.Message) tg.PeerClass {
+	if msg.FromID != nil {
+		return msg.FromID
+	}
+	return msg.PeerID
+}
+
+// senderKey identifies a peer across kinds, since user, chat and channel IDs can collide.
+type senderKey struct {
+	typeID uint32
+	id     int64
+}
+
+// cachedSenderLabel returns the sender label of msg, looking it up once per sender.
+func cachedSenderLabel(ctx context.Context, names map[senderKey]string, msg *tg.Message) string {
+	from := messageSender(msg)
+	key := senderKey{id: peerToID(from)}
+	if from != nil {
+		key.typeID = from.TypeID()
+	}
+	if name, ok := names[key]; ok {
+		return name
+	}
+	name := senderLabel(ctx, from)
+	names[key] = name
+	return name
+}
+
+// senderLabel returns the sender's display name, falling back to the numeric ID.
+func senderLabel(ctx context.Context, from tg.PeerClass) string {
+	if name := peerDisplayName(ctx, from); name != "" {
+		return name
+	}
+	return strconv.FormatInt(peerToID(from), 10)
+}
+
 func extractMessages(ctx context.Context, result tg.MessagesMessagesClass) []tg.MessageClass {
 	modified, ok := result.AsModified()
 	if !ok {
@@ -68,6 +160,27 @@ func extractMessages(ctx context.Context, result tg.MessagesMessagesClass) []tg.
 	return modified.GetMessages()
 }
 
+// sentMessageIDs extracts the IDs of newly sent messages from an Updates response.
+func sentMessageIDs(result tg.UpdatesClass) []int {
+	var updates []tg.UpdateClass
+	switch u := result.(type) {
+	case *tg.Updates:
+		updates = u.Updates
+	case *tg.UpdatesCombined:
+		updates = u.Updates
+	case *tg.UpdateShortSentMessage:
+		return []int{u.ID}
+	}
+
+	var ids []int
+	for _, update := range updates {
+		if u, ok := update.(*tg.UpdateMessageID); ok {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids
+}
+
 func parseMessageIDs(s string) ([]int, error) {
 	parts := strings.Split(s, ",")
 	if len(parts) > 100 {
@@ -98,6 +211,17 @@ type sendMessageInput struct {
 	Message      string `json:"message" jsonschema:"required"`
 	ReplyToMsgID int    `json:"reply_to_msg_id"`
 	ScheduleDate int    `json:"schedule_date"`
+	AllowSplit   bool   `json:"allow_split"`
+	TopicID      int    `json:"topic_id"`
+	NoWebpage    bool   `json:"no_webpage"`
+}
+
+// Send Message Group
+
+type sendMessageGroupInput struct {
+	Peer         string   `json:"peer" jsonschema:"required"`
+	Messages     []string `json:"messages" jsonschema:"required"`
+	ReplyToMsgID int      `json:"reply_to_msg_id"`
 }
 
 // Get History
@@ -106,13 +230,73 @@ type getHistoryInput struct {
 	Peer     string `json:"peer" jsonschema:"required"`
 	Limit    int    `json:"limit"`
 	OffsetID int    `json:"offset_id"`
+	Markdown bool   `json:"markdown"`
+}
+
+// Saved Messages
+
+type getSavedMessagesInput struct {
+	Limit    int `json:"limit"`
+	OffsetID int `json:"offset_id"`
+}
+
+type saveToSavedInput struct {
+	FromPeer   string `json:"from_peer" jsonschema:"required"`
+	MessageIDs string `json:"message_ids" jsonschema:"required"`
+	Copy       bool   `json:"copy"`
+}
+
+// Saved Dialogs
+
+type getSavedDialogsInput struct {
+	Limit int `json:"limit"`
+}
+
+type getSavedHistoryInput struct {
+	Peer     string `json:"peer" jsonschema:"required"`
+	Limit    int    `json:"limit"`
+	OffsetID int    `json:"offset_id"`
+}
+
+type pinSavedDialogInput struct {
+	Peer   string `json:"peer" jsonschema:"required"`
+	Pinned *bool  `json:"pinned"`
+}
+
+// Get Messages By IDs
+
+type getMessagesByIDsInput struct {
+	Peer       string `json:"peer" jsonschema:"required"`
+	MessageIDs string `json:"message_ids" jsonschema:"required"`
+}
+
+// Get Replied Message
+
+type getRepliedMessageInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	MessageID int    `json:"message_id" jsonschema:"required"`
+}
+
+// Get Message Author
+
+type getMessageAuthorInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	MessageID int    `json:"message_id" jsonschema:"required"`
 }
 
 // Search Messages
 
 type searchMessagesInput struct {
+	Peer     string `json:"peer" jsonschema:"required"`
+	Query    string `json:"query" jsonschema:"required"`
+	Limit    int    `json:"limit"`
+	Markdown bool   `json:"markdown"`
+}
+
+// Get Chat Links
+
+type getChatLinksInput struct {
 	Peer  string `json:"peer" jsonschema:"required"`
-	Query string `json:"query" jsonschema:"required"`
 	Limit int    `json:"limit"`
 }
 
@@ -124,6 +308,15 @@ type forwardMessageInput struct {
 	MessageIDs string `json:"message_ids" jsonschema:"required"`
 }
 
+// Copy Messages
+
+type copyMessagesInput struct {
+	FromPeer     string `json:"from_peer" jsonschema:"required"`
+	ToPeer       string `json:"to_peer" jsonschema:"required"`
+	MessageIDs   string `json:"message_ids" jsonschema:"required"`
+	DropCaptions bool   `json:"drop_captions"`
+}
+
 // Delete Message
 
 type deleteMessageInput struct {
@@ -138,6 +331,7 @@ type editMessageInput struct {
 	Peer      string `json:"peer" jsonschema:"required"`
 	MessageID int    `json:"message_id" jsonschema:"required"`
 	Message   string `json:"message" jsonschema:"required"`
+	NoWebpage bool   `json:"no_webpage"`
 }
 
 // Pin Message
@@ -148,6 +342,19 @@ type pinMessageInput struct {
 	Silent    bool   `json:"silent"`
 }
 
+// Get Pinned Message
+
+type getPinnedMessageInput struct {
+	Peer string `json:"peer" jsonschema:"required"`
+}
+
+// Get Recent Locations
+
+type getRecentLocationsInput struct {
+	Peer  string `json:"peer" jsonschema:"required"`
+	Limit int    `json:"limit"`
+}
+
 // Search Global
 
 type searchGlobalInput struct {
@@ -155,6 +362,13 @@ type searchGlobalInput struct {
 	Limit int    `json:"limit"`
 }
 
+// Search Posts
+
+type searchPostsInput struct {
+	Hashtag string `json:"hashtag" jsonschema:"required"`
+	Limit   int    `json:"limit"`
+}
+
 // Read History
 
 type readHistoryInput struct {
@@ -191,6 +405,27 @@ type translateInput struct {
 	ToLang    string `json:"to_lang" jsonschema:"required"`
 }
 
+// Translate Chat
+
+type translateChatInput struct {
+	Peer   string `json:"peer" jsonschema:"required"`
+	ToLang string `json:"to_lang" jsonschema:"required"`
+	Limit  int    `json:"limit"`
+}
+
+// Get Discussion Message
+
+type getDiscussionMessageInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	MessageID int    `json:"message_id" jsonschema:"required"`
+}
+
+// Get Web Page
+
+type getWebPageInput struct {
+	URL string `json:"url" jsonschema:"required"`
+}
+
 // Send Poll
 
 type sendPollInput struct {
@@ -200,6 +435,7 @@ type sendPollInput struct {
 	MultipleChoice bool   `json:"multiple_choice"`
 	Quiz           bool   `json:"quiz"`
 	CorrectOption  int    `json:"correct_option"`
+	TopicID        int    `json:"topic_id"`
 }
 
 func RegisterMessageTools(s *server.MCPServer) {
@@ -212,10 +448,25 @@ func RegisterMessageTools(s *server.MCPServer) {
 			mcp.WithString("message", mcp.Required(), mcp.Description("Message text to send")),
 			mcp.WithNumber("reply_to_msg_id", mcp.Description("Message ID to reply to (optional)")),
 			mcp.WithNumber("schedule_date", mcp.Description("Unix timestamp to schedule message for future delivery")),
+			mcp.WithBoolean("allow_split", mcp.Description("Split messages over 4096 characters into several messages on paragraph/sentence boundaries (default false)")),
+			mcp.WithNumber("topic_id", mcp.Description("Forum topic ID to post into (supergroups with topics enabled)")),
+			mcp.WithBoolean("no_webpage", mcp.Description("Disable the link preview for URLs in the message (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleSendMessage),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_send_message_group",
+			mcp.WithDescription("Send several messages in sequence, each replying to the previous one, e.g. to post content longer than one message allows"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithArray("messages", mcp.Required(), mcp.WithStringItems(), mcp.Description("Message texts to send, in order (max 10)")),
+			mcp.WithNumber("reply_to_msg_id", mcp.Description("Message ID the first message replies to (optional)")),
+		),
+		mcp.NewTypedToolHandler(handleSendMessageGroup),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_get_history",
 			mcp.WithDescription("Get message history from a Telegram chat"),
@@ -224,10 +475,100 @@ func RegisterMessageTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("limit", mcp.Description("Number of messages to retrieve (default 20)")),
 			mcp.WithNumber("offset_id", mcp.Description("Offset message ID for pagination (default 0)")),
+			mcp.WithBoolean("markdown", mcp.Description("Render formatting, links and mentions as Markdown (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleGetHistory),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_saved_messages",
+			mcp.WithDescription("Get recent messages from your Saved Messages chat"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("limit", mcp.Description("Number of messages to retrieve (default 20)")),
+			mcp.WithNumber("offset_id", mcp.Description("Offset message ID for pagination (default 0)")),
+		),
+		mcp.NewTypedToolHandler(handleGetSavedMessages),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_save_to_saved",
+			mcp.WithDescription("Forward or copy messages into your Saved Messages chat"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("from_peer", mcp.Required(), mcp.Description("Source chat ID or @username")),
+			mcp.WithString("message_ids", mcp.Required(), mcp.Description("Comma-separated message IDs to save")),
+			mcp.WithBoolean("copy", mcp.Description("Save as a copy without the forward header (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleSaveToSaved),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_saved_dialogs",
+			mcp.WithDescription("List the saved dialogs inside Saved Messages, grouped by the chat the messages were saved from"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("limit", mcp.Description("Number of saved dialogs to retrieve (default 20)")),
+		),
+		mcp.NewTypedToolHandler(handleGetSavedDialogs),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_saved_history",
+			mcp.WithDescription("Get the messages of one saved dialog inside Saved Messages"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username the messages were saved from")),
+			mcp.WithNumber("limit", mcp.Description("Number of messages to retrieve (default 20)")),
+			mcp.WithNumber("offset_id", mcp.Description("Offset message ID for pagination (default 0)")),
+		),
+		mcp.NewTypedToolHandler(handleGetSavedHistory),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_pin_saved_dialog",
+			mcp.WithDescription("Pin or unpin a saved dialog inside Saved Messages"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username of the saved dialog")),
+			mcp.WithBoolean("pinned", mcp.Description("true to pin, false to unpin (default true)")),
+		),
+		mcp.NewTypedToolHandler(handlePinSavedDialog),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_messages_by_ids",
+			mcp.WithDescription("Get specific messages from a Telegram chat by their IDs"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithString("message_ids", mcp.Required(), mcp.Description("Comma-separated message IDs to fetch")),
+		),
+		mcp.NewTypedToolHandler(handleGetMessagesByIDs),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_replied_message",
+			mcp.WithDescription("Get the message that a given message replies to, shown together with the reply"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the reply message")),
+		),
+		mcp.NewTypedToolHandler(handleGetRepliedMessage),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_message_author",
+			mcp.WithDescription("Identify who wrote a message, including signed channel posts and messages from anonymous group admins, matching the signature against the admin list where possible"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message")),
+		),
+		mcp.NewTypedToolHandler(handleGetMessageAuthor),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_search_messages",
 			mcp.WithDescription("Search messages in a Telegram chat"),
@@ -236,10 +577,22 @@ func RegisterMessageTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithString("query", mcp.Required(), mcp.Description("Search query string")),
 			mcp.WithNumber("limit", mcp.Description("Maximum number of results (default 20)")),
+			mcp.WithBoolean("markdown", mcp.Description("Render formatting, links and mentions as Markdown (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleSearchMessages),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_chat_links",
+			mcp.WithDescription("Extract the URLs shared in a chat, deduplicated, with the IDs of the messages that shared them"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("limit", mcp.Description("Number of recent messages with links to scan (default 100, max 500)")),
+		),
+		mcp.NewTypedToolHandler(handleGetChatLinks),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_forward_message",
 			mcp.WithDescription("Forward messages between Telegram chats"),
@@ -252,6 +605,19 @@ func RegisterMessageTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleForwardMessage),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_copy_messages",
+			mcp.WithDescription("Copy messages to another chat without the \"forwarded from\" header, so they appear as original content"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("from_peer", mcp.Required(), mcp.Description("Source chat ID or @username")),
+			mcp.WithString("to_peer", mcp.Required(), mcp.Description("Destination chat ID or @username")),
+			mcp.WithString("message_ids", mcp.Required(), mcp.Description("Comma-separated message IDs to copy")),
+			mcp.WithBoolean("drop_captions", mcp.Description("Also remove captions from copied media (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleCopyMessages),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_delete_message",
 			mcp.WithDescription("Delete messages from a Telegram chat"),
@@ -272,6 +638,7 @@ func RegisterMessageTools(s *server.MCPServer) {
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message to edit")),
 			mcp.WithString("message", mcp.Required(), mcp.Description("New message text")),
+			mcp.WithBoolean("no_webpage", mcp.Description("Remove the link preview from the edited message (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleEditMessage),
 	)
@@ -288,6 +655,27 @@ func RegisterMessageTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handlePinMessage),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_get_pinned_message",
+			mcp.WithDescription("Get the currently pinned message of a chat with its full content and media info"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+		),
+		mcp.NewTypedToolHandler(handleGetPinnedMessage),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_recent_locations",
+			mcp.WithDescription("Get live and recent location messages shared in a chat"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of locations (default 20)")),
+		),
+		mcp.NewTypedToolHandler(handleGetRecentLocations),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_search_global",
 			mcp.WithDescription("Search messages across all chats globally"),
@@ -299,6 +687,17 @@ func RegisterMessageTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleSearchGlobal),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_search_posts",
+			mcp.WithDescription("Search public channel posts across all of Telegram by hashtag, grouped by channel"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("hashtag", mcp.Required(), mcp.Description("Hashtag to search for, with or without the leading #")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of posts (default 20, max 100)")),
+		),
+		mcp.NewTypedToolHandler(handleSearchPosts),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_read_history",
 			mcp.WithDescription("Mark messages as read in a Telegram chat"),
@@ -355,6 +754,39 @@ func RegisterMessageTools(s *server.MCPServer) {
 		mcp.NewTypedToolHandler(handleTranslate),
 	)
 
+	s.AddTool(
+		mcp.NewTool("telegram_translate_chat",
+			mcp.WithDescription("Translate the most recent messages of a chat to a specified language, paired with the originals"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
+			mcp.WithString("to_lang", mcp.Required(), mcp.Description("Two-letter ISO 639-1 language code (e.g. \"en\", \"vi\", \"ja\")")),
+			mcp.WithNumber("limit", mcp.Description("Number of recent messages to translate (default 20, max 100)")),
+		),
+		mcp.NewTypedToolHandler(handleTranslateChat),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_discussion_message",
+			mcp.WithDescription("Map a channel post to its message in the linked discussion group, returning the IDs needed to read or post comments"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Channel ID or @username")),
+			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the channel post")),
+		),
+		mcp.NewTypedToolHandler(handleGetDiscussionMessage),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_webpage",
+			mcp.WithDescription("Get the link preview Telegram would generate for a URL (title, description, site name) without sending anything"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("url", mcp.Required(), mcp.Description("URL to preview")),
+		),
+		mcp.NewTypedToolHandler(handleGetWebPage),
+	)
+
 	s.AddTool(
 		mcp.NewTool("telegram_send_poll",
 			mcp.WithDescription("Send a poll to a Telegram chat"),
@@ -366,36 +798,103 @@ func RegisterMessageTools(s *server.MCPServer) {
 			mcp.WithBoolean("multiple_choice", mcp.Description("Allow multiple answers")),
 			mcp.WithBoolean("quiz", mcp.Description("Quiz mode with correct answer")),
 			mcp.WithNumber("correct_option", mcp.Description("0-indexed correct option for quiz mode")),
+			mcp.WithNumber("topic_id", mcp.Description("Forum topic ID to post into (supergroups with topics enabled)")),
 		),
 		mcp.NewTypedToolHandler(handleSendPoll),
 	)
 }
 
+// messageReplyTo builds the reply header for a reply and/or a forum topic.
+// Posting into a topic is a reply to the topic's root message.
+func messageReplyTo(peer tg.InputPeerClass, replyToMsgID, topicID int) (*tg.InputReplyToMessage, error) {
+	if topicID == 0 {
+		if replyToMsgID == 0 {
+			return nil, nil
+		}
+		return &tg.InputReplyToMessage{ReplyToMsgID: replyToMsgID}, nil
+	}
+
+	if _, ok := peer.(*tg.InputPeerChannel); !ok {
+		return nil, fmt.Errorf("topic_id can only be used with a supergroup that has forum topics enabled")
+	}
+
+	replyTo := &tg.InputReplyToMessage{ReplyToMsgID: topicID}
+	if replyToMsgID != 0 {
+		replyTo.ReplyToMsgID = replyToMsgID
+	}
+	replyTo.SetTopMsgID(topicID)
+	return replyTo, nil
+}
+
 func handleSendMessage(_ context.Context, _ mcp.CallToolRequest, input sendMessageInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
+	if strings.TrimSpace(input.Message) == "" {
+		return mcp.NewToolResultError("message must not be empty"), nil
+	}
+
 	peer, err := services.ResolvePeer(tgCtx, input.Peer)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
-	req := &tg.MessagesSendMessageRequest{
-		Peer:     peer,
-		Message:  input.Message,
-		RandomID: randomID(),
+	replyTo, err := messageReplyTo(peer, input.ReplyToMsgID, input.TopicID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	if input.ReplyToMsgID != 0 {
-		req.SetReplyTo(&tg.InputReplyToMessage{ReplyToMsgID: input.ReplyToMsgID})
+	parts := []string{input.Message}
+	if input.AllowSplit {
+		parts = splitMessage(input.Message, maxMessageLength)
+		if len(parts) == 0 {
+			return mcp.NewToolResultError("message has no text to send"), nil
+		}
 	}
 
-	if input.ScheduleDate > 0 {
-		req.SetScheduleDate(input.ScheduleDate)
+	var sentIDs []string
+	for i, part := range parts {
+		req := &tg.MessagesSendMessageRequest{
+			Peer:      peer,
+			Message:   part,
+			RandomID:  randomID(),
+			NoWebpage: input.NoWebpage,
+		}
+
+		if replyTo != nil {
+			req.SetReplyTo(replyTo)
+		}
+
+		if input.ScheduleDate > 0 {
+			req.SetScheduleDate(input.ScheduleDate)
+		}
+
+		var result tg.UpdatesClass
+		err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+			var err error
+			result, err = services.API().MessagesSendMessage(ctx, req)
+			return err
+		})
+		if err != nil {
+			if tg.IsMessageTooLong(err) && !input.AllowSplit {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to send message: %v (set allow_split=true to send it as several messages)", err)), nil
+			}
+			if len(parts) > 1 {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to send part %d of %d: %v (already sent message IDs: %s)", i+1, len(parts), err, strings.Join(sentIDs, ", "))), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("failed to send message: %v", err)), nil
+		}
+
+		for _, id := range sentMessageIDs(result) {
+			sentIDs = append(sentIDs, strconv.Itoa(id))
+		}
 	}
 
-	_, err = services.API().MessagesSendMessage(tgCtx, req)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to send message: %v", err)), nil
+	if len(parts) > 1 {
+		verb := "Sent"
+		if input.ScheduleDate > 0 {
+			verb = "Scheduled"
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s message split into %d parts. Message IDs: %s", verb, len(parts), strings.Join(sentIDs, ", "))), nil
 	}
 
 	if input.ScheduleDate > 0 {
@@ -404,9 +903,287 @@ func handleSendMessage(_ context.Context, _ mcp.CallToolRequest, input sendMessa
 	return mcp.NewToolResultText("Message sent successfully."), nil
 }
 
-func handleGetHistory(_ context.Context, _ mcp.CallToolRequest, input getHistoryInput) (*mcp.CallToolResult, error) {
-	tgCtx := services.Context()
-
+// maxMessageLength is Telegram's limit for a single text message, in UTF-16 code units.
+const maxMessageLength = 4096
+
+// splitMessage splits text into parts of at most limit UTF-16 code units, preferring
+// paragraph breaks, then line breaks, then sentence ends, then spaces.
+func splitMessage(text string, limit int) []string {
+	var parts []string
+	for utf16Len(text) > limit {
+		cut := splitPoint(text, limit)
+		if part := strings.TrimRightFunc(text[:cut], unicode.IsSpace); part != "" {
+			parts = append(parts, part)
+		}
+		text = strings.TrimLeftFunc(text[cut:], unicode.IsSpace)
+	}
+	if text != "" {
+		parts = append(parts, text)
+	}
+	return parts
+}
+
+// splitPoint returns the byte offset at which to cut text so the first part fits in limit.
+func splitPoint(text string, limit int) int {
+	end, units := 0, 0
+	for i, r := range text {
+		n := 1
+		if r > 0xFFFF {
+			n = 2
+		}
+		if units+n > limit {
+			break
+		}
+		units += n
+		end = i + utf8.RuneLen(r)
+	}
+	prefix := text[:end]
+
+	for _, sep := range []string{"\n\n", "\n", ". ", "! ", "? ", " "} {
+		if idx := strings.LastIndex(prefix, sep); idx > 0 {
+			return idx + len(sep)
+		}
+	}
+	return end
+}
+
+// utf16Len returns the length of s in UTF-16 code units, which is how Telegram measures text.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r > 0xFFFF {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+func handleSendMessageGroup(_ context.Context, _ mcp.CallToolRequest, input sendMessageGroupInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	if len(input.Messages) == 0 {
+		return mcp.NewToolResultError("messages must not be empty"), nil
+	}
+	if len(input.Messages) > 10 {
+		return mcp.NewToolResultError("too many messages (max 10)"), nil
+	}
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	var sentIDs []string
+	replyTo := input.ReplyToMsgID
+	for i, text := range input.Messages {
+		req := &tg.MessagesSendMessageRequest{
+			Peer:     peer,
+			Message:  text,
+			RandomID: randomID(),
+		}
+		if replyTo != 0 {
+			req.SetReplyTo(&tg.InputReplyToMessage{ReplyToMsgID: replyTo})
+		}
+
+		var result tg.UpdatesClass
+		err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+			var err error
+			result, err = services.API().MessagesSendMessage(ctx, req)
+			return err
+		})
+		if err != nil {
+			msg := fmt.Sprintf("failed to send message %d of %d: %v", i+1, len(input.Messages), err)
+			if len(sentIDs) > 0 {
+				msg += fmt.Sprintf(" (already sent message IDs: %s)", strings.Join(sentIDs, ", "))
+			}
+			return mcp.NewToolResultError(msg), nil
+		}
+
+		ids := sentMessageIDs(result)
+		if len(ids) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("message %d of %d sent but its ID could not be determined, stopping the chain", i+1, len(input.Messages))), nil
+		}
+		replyTo = ids[0]
+		sentIDs = append(sentIDs, strconv.Itoa(replyTo))
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Sent %d message(s). Message IDs: %s", len(sentIDs), strings.Join(sentIDs, ", "))), nil
+}
+
+func handleGetSavedMessages(_ context.Context, _ mcp.CallToolRequest, input getSavedMessagesInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetHistory(tgCtx, &tg.MessagesGetHistoryRequest{
+		Peer:     &tg.InputPeerSelf{},
+		Limit:    limit,
+		OffsetID: input.OffsetID,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get saved messages: %v", err)), nil
+	}
+
+	msgs := extractMessages(tgCtx, result)
+	return mcp.NewToolResultText(formatMessages(tgCtx, msgs)), nil
+}
+
+func handleSaveToSaved(_ context.Context, _ mcp.CallToolRequest, input saveToSavedInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	fromPeer, err := services.ResolvePeer(tgCtx, input.FromPeer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve from_peer: %v", err)), nil
+	}
+
+	ids, err := parseMessageIDs(input.MessageIDs)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid message_ids: %v", err)), nil
+	}
+
+	randomIDs := make([]int64, len(ids))
+	for i := range randomIDs {
+		randomIDs[i] = randomID()
+	}
+
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		_, err := services.API().MessagesForwardMessages(ctx, &tg.MessagesForwardMessagesRequest{
+			FromPeer:   fromPeer,
+			ToPeer:     &tg.InputPeerSelf{},
+			ID:         ids,
+			RandomID:   randomIDs,
+			DropAuthor: input.Copy,
+		})
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to save messages: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Saved %d message(s) to Saved Messages.", len(ids))), nil
+}
+
+func handleGetSavedDialogs(_ context.Context, _ mcp.CallToolRequest, input getSavedDialogsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetSavedDialogs(tgCtx, &tg.MessagesGetSavedDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get saved dialogs: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok || len(modified.GetDialogs()) == 0 {
+		return mcp.NewToolResultText("No saved dialogs found."), nil
+	}
+
+	services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+	topMessages := make(map[int]*tg.Message)
+	for _, mc := range modified.GetMessages() {
+		if msg, ok := mc.(*tg.Message); ok {
+			topMessages[msg.ID] = msg
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Saved dialogs (%d):\n", len(modified.GetDialogs()))
+	for _, dc := range modified.GetDialogs() {
+		d, ok := dc.(*tg.SavedDialog)
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n%s (ID: %d)", senderLabel(tgCtx, d.Peer), peerToID(d.Peer))
+		if d.Pinned {
+			sb.WriteString(" [pinned]")
+		}
+		if msg, ok := topMessages[d.TopMessage]; ok {
+			t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+			fmt.Fprintf(&sb, "\n  Last: [%d] (%s) %s", msg.ID, t, truncateText(msg.Message, 100))
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetSavedHistory(_ context.Context, _ mcp.CallToolRequest, input getSavedHistoryInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetSavedHistory(tgCtx, &tg.MessagesGetSavedHistoryRequest{
+		Peer:     peer,
+		Limit:    limit,
+		OffsetID: input.OffsetID,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get saved history: %v", err)), nil
+	}
+
+	msgs := extractMessages(tgCtx, result)
+	return mcp.NewToolResultText(formatMessages(tgCtx, msgs)), nil
+}
+
+func handlePinSavedDialog(_ context.Context, _ mcp.CallToolRequest, input pinSavedDialogInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	pinned := true
+	if input.Pinned != nil {
+		pinned = *input.Pinned
+	}
+
+	_, err = services.API().MessagesToggleSavedDialogPin(tgCtx, &tg.MessagesToggleSavedDialogPinRequest{
+		Peer:   &tg.InputDialogPeer{Peer: peer},
+		Pinned: pinned,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to toggle saved dialog pin: %v", err)), nil
+	}
+
+	if pinned {
+		return mcp.NewToolResultText("Saved dialog pinned successfully."), nil
+	}
+	return mcp.NewToolResultText("Saved dialog unpinned successfully."), nil
+}
+
+func handleGetHistory(_ context.Context, _ mcp.CallToolRequest, input getHistoryInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
 	peer, err := services.ResolvePeer(tgCtx, input.Peer)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
@@ -430,7 +1207,190 @@ func handleGetHistory(_ context.Context, _ mcp.CallToolRequest, input getHistory
 	}
 
 	msgs := extractMessages(tgCtx, result)
-	return mcp.NewToolResultText(formatMessages(msgs)), nil
+	return mcp.NewToolResultText(formatMessageList(tgCtx, msgs, input.Markdown)), nil
+}
+
+func handleGetMessagesByIDs(_ context.Context, _ mcp.CallToolRequest, input getMessagesByIDsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	ids, err := parseMessageIDs(input.MessageIDs)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid message_ids: %v", err)), nil
+	}
+
+	msgs, err := getMessagesByIDs(tgCtx, peer, ids)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get messages: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatMessages(tgCtx, msgs)), nil
+}
+
+func handleGetRepliedMessage(_ context.Context, _ mcp.CallToolRequest, input getRepliedMessageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	msg, err := getMessageByID(tgCtx, peer, input.MessageID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get message: %v", err)), nil
+	}
+
+	replyTo, ok := msg.GetReplyTo()
+	if !ok {
+		return mcp.NewToolResultText(fmt.Sprintf("Message %d is not a reply.", msg.ID)), nil
+	}
+	header, ok := replyTo.(*tg.MessageReplyHeader)
+	if !ok {
+		return mcp.NewToolResultText(fmt.Sprintf("Message %d replies to a story, not a message.", msg.ID)), nil
+	}
+	parentID, ok := header.GetReplyToMsgID()
+	if !ok {
+		return mcp.NewToolResultText(fmt.Sprintf("Message %d has no reply target.", msg.ID)), nil
+	}
+
+	// Replies can point to a message in another chat (e.g. a linked channel)
+	parentPeer := peer
+	if replyPeer, ok := header.GetReplyToPeerID(); ok {
+		parentPeer, err = services.GetInputPeerByID(tgCtx, peerToID(replyPeer))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to resolve reply peer: %v", err)), nil
+		}
+	}
+
+	parent, err := getMessageByID(tgCtx, parentPeer, parentID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get replied message: %v", err)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("In reply to:\n")
+	sb.WriteString(formatMessages(tgCtx, []tg.MessageClass{parent}))
+	sb.WriteString("\nReply:\n")
+	sb.WriteString(formatMessages(tgCtx, []tg.MessageClass{msg}))
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetMessageAuthor(_ context.Context, _ mcp.CallToolRequest, input getMessageAuthorInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	msg, err := getMessageByID(tgCtx, peer, input.MessageID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get message: %v", err)), nil
+	}
+
+	signature, _ := msg.GetPostAuthor()
+
+	var sb strings.Builder
+	switch from := msg.FromID.(type) {
+	case *tg.PeerUser:
+		fmt.Fprintf(&sb, "Author: %s (ID: %d)\n", senderLabel(tgCtx, from), from.UserID)
+		if signature != "" {
+			fmt.Fprintf(&sb, "Signature: %s\n", signature)
+		}
+		return mcp.NewToolResultText(sb.String()), nil
+	case *tg.PeerChannel:
+		if from.ChannelID != peerToID(msg.PeerID) {
+			fmt.Fprintf(&sb, "Sent on behalf of channel: %s (ID: %d)\n", senderLabel(tgCtx, from), from.ChannelID)
+			if signature != "" {
+				fmt.Fprintf(&sb, "Signature: %s\n", signature)
+			}
+			return mcp.NewToolResultText(sb.String()), nil
+		}
+		sb.WriteString("Sent by an anonymous admin of this group.\n")
+	case *tg.PeerChat:
+		fmt.Fprintf(&sb, "Sent on behalf of chat: %s (ID: %d)\n", senderLabel(tgCtx, from), from.ChatID)
+		return mcp.NewToolResultText(sb.String()), nil
+	default:
+		sb.WriteString("Channel post.\n")
+	}
+
+	if signature == "" {
+		sb.WriteString("No signature; the author can't be identified.\n")
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+	fmt.Fprintf(&sb, "Signature: %s\n", signature)
+
+	inputChannel, ok := toInputChannel(peer)
+	if !ok {
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+
+	// Anonymous admins sign with their custom title, channel authors with their name
+	admins, err := services.API().ChannelsGetParticipants(tgCtx, &tg.ChannelsGetParticipantsRequest{
+		Channel: inputChannel,
+		Filter:  &tg.ChannelParticipantsAdmins{},
+		Limit:   200,
+	})
+	if err != nil {
+		fmt.Fprintf(&sb, "Could not check the admin list: %v\n", err)
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+	cp, ok := admins.(*tg.ChannelsChannelParticipants)
+	if !ok {
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+	services.StorePeers(tgCtx, cp.Chats, cp.Users)
+
+	userMap := make(map[int64]*tg.User)
+	for _, u := range cp.Users {
+		if user, ok := u.(*tg.User); ok {
+			userMap[user.ID] = user
+		}
+	}
+
+	var candidates []*tg.User
+	for _, p := range cp.Participants {
+		var userID int64
+		var rank string
+		switch v := p.(type) {
+		case *tg.ChannelParticipantCreator:
+			userID, rank = v.UserID, v.Rank
+		case *tg.ChannelParticipantAdmin:
+			userID, rank = v.UserID, v.Rank
+		default:
+			continue
+		}
+		user, ok := userMap[userID]
+		if !ok {
+			continue
+		}
+		name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+		if strings.EqualFold(rank, signature) || strings.EqualFold(name, signature) {
+			candidates = append(candidates, user)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		sb.WriteString("No admin matches the signature.\n")
+	case 1:
+		sb.WriteString("Likely author: ")
+		formatUserInline(&sb, candidates[0])
+		sb.WriteString("\n")
+	default:
+		fmt.Fprintf(&sb, "Possible authors (%d admins match the signature):\n", len(candidates))
+		for _, user := range candidates {
+			sb.WriteString("  ")
+			formatUserInline(&sb, user)
+			sb.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
 }
 
 func handleSearchMessages(_ context.Context, _ mcp.CallToolRequest, input searchMessagesInput) (*mcp.CallToolResult, error) {
@@ -460,10 +1420,256 @@ func handleSearchMessages(_ context.Context, _ mcp.CallToolRequest, input search
 	}
 
 	msgs := extractMessages(tgCtx, result)
-	return mcp.NewToolResultText(formatMessages(msgs)), nil
+	return mcp.NewToolResultText(formatMessageList(tgCtx, msgs, input.Markdown)), nil
+}
+
+func handleGetChatLinks(_ context.Context, _ mcp.CallToolRequest, input getChatLinksInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	type sharedLink struct {
+		title string
+		msgs  []int
+	}
+
+	links := make(map[string]*sharedLink)
+	var order []string
+	add := func(url, title string, msgID int) {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			return
+		}
+		l, ok := links[url]
+		if !ok {
+			l = &sharedLink{}
+			links[url] = l
+			order = append(order, url)
+		}
+		if l.title == "" {
+			l.title = title
+		}
+		if len(l.msgs) == 0 || l.msgs[len(l.msgs)-1] != msgID {
+			l.msgs = append(l.msgs, msgID)
+		}
+	}
+
+	scanned, offsetID := 0, 0
+	for scanned < limit {
+		result, err := services.API().MessagesSearch(tgCtx, &tg.MessagesSearchRequest{
+			Peer:     peer,
+			Filter:   &tg.InputMessagesFilterURL{},
+			OffsetID: offsetID,
+			Limit:    min(100, limit-scanned),
+		})
+		if err != nil {
+			if scanned > 0 {
+				break
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("failed to search messages: %v", err)), nil
+		}
+
+		msgs := extractMessages(tgCtx, result)
+		if len(msgs) == 0 {
+			break
+		}
+
+		for _, mc := range msgs {
+			msg, ok := mc.(*tg.Message)
+			if !ok {
+				continue
+			}
+			offsetID = msg.ID
+			scanned++
+
+			for _, e := range msg.Entities {
+				switch ent := e.(type) {
+				case *tg.MessageEntityURL:
+					add(entityText(msg.Message, ent.Offset, ent.Length), "", msg.ID)
+				case *tg.MessageEntityTextURL:
+					add(ent.URL, "", msg.ID)
+				}
+			}
+			if media, ok := msg.Media.(*tg.MessageMediaWebPage); ok {
+				if page, ok := media.Webpage.(*tg.WebPage); ok {
+					add(page.URL, page.Title, msg.ID)
+				}
+			}
+		}
+
+		if len(msgs) < 100 {
+			break
+		}
+	}
+
+	if len(order) == 0 {
+		return mcp.NewToolResultText("No links found."), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Links shared (%d unique, from %d messages):\n", len(order), scanned)
+	for _, url := range order {
+		l := links[url]
+		fmt.Fprintf(&sb, "\n%s\n", url)
+		if l.title != "" {
+			fmt.Fprintf(&sb, "  Title: %s\n", l.title)
+		}
+		ids := make([]string, len(l.msgs))
+		for i, id := range l.msgs {
+			ids[i] = strconv.Itoa(id)
+		}
+		fmt.Fprintf(&sb, "  Messages: %s\n", strings.Join(ids, ", "))
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// entityText returns the part of text covered by a message entity.
+// Entity offsets and lengths are counted in UTF-16 code units.
+func entityText(text string, offset, length int) string {
+	units := utf16.Encode([]rune(text))
+	if offset < 0 || length <= 0 || offset+length > len(units) {
+		return ""
+	}
+	return string(utf16.Decode(units[offset : offset+length]))
+}
+
+// renderEntities rebuilds a Markdown-style representation of text from its
+// formatting entities. Offsets are in UTF-16 code units, so the text is sliced
+// as UTF-16 and decoded back to UTF-8 piece by piece.
+func renderEntities(text string, entities []tg.MessageEntityClass) string {
+	units := utf16.Encode([]rune(text))
+
+	type marker struct {
+		pos   int
+		open  bool
+		rank  int
+		token string
+	}
+
+	sorted := make([]tg.MessageEntityClass, len(entities))
+	copy(sorted, entities)
+	// Outer entities first so that nested ones open after and close before them
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].GetOffset() != sorted[j].GetOffset() {
+			return sorted[i].GetOffset() < sorted[j].GetOffset()
+		}
+		return sorted[i].GetLength() > sorted[j].GetLength()
+	})
+
+	var markers []marker
+	for i, e := range sorted {
+		start, end := e.GetOffset(), e.GetOffset()+e.GetLength()
+		if start < 0 || e.GetLength() <= 0 || end > len(units) {
+			continue
+		}
+
+		var open, closing string
+		switch e := e.(type) {
+		case *tg.MessageEntityBold:
+			open, closing = "**", "**"
+		case *tg.MessageEntityItalic:
+			open, closing = "_", "_"
+		case *tg.MessageEntityUnderline:
+			open, closing = "__", "__"
+		case *tg.MessageEntityStrike:
+			open, closing = "~~", "~~"
+		case *tg.MessageEntitySpoiler:
+			open, closing = "||", "||"
+		case *tg.MessageEntityCode:
+			open, closing = "`", "`"
+		case *tg.MessageEntityPre:
+			open, closing = "```"+e.Language+"\n", "\n```"
+		case *tg.MessageEntityTextURL:
+			open, closing = "[", "]("+e.URL+")"
+		case *tg.MessageEntityMentionName:
+			open, closing = "[", fmt.Sprintf("](tg://user?id=%d)", e.UserID)
+		default:
+			continue
+		}
+
+		markers = append(markers,
+			marker{pos: start, open: true, rank: i, token: open},
+			marker{pos: end, open: false, rank: -i, token: closing},
+		)
+	}
+	if len(markers) == 0 {
+		return text
+	}
+
+	// At the same position entities close before new ones open
+	sort.SliceStable(markers, func(i, j int) bool {
+		if markers[i].pos != markers[j].pos {
+			return markers[i].pos < markers[j].pos
+		}
+		if markers[i].open != markers[j].open {
+			return !markers[i].open
+		}
+		return markers[i].rank < markers[j].rank
+	})
+
+	var sb strings.Builder
+	prev := 0
+	for _, m := range markers {
+		sb.WriteString(string(utf16.Decode(units[prev:m.pos])))
+		sb.WriteString(m.token)
+		prev = m.pos
+	}
+	sb.WriteString(string(utf16.Decode(units[prev:])))
+	return sb.String()
+}
+
+func handleForwardMessage(_ context.Context, _ mcp.CallToolRequest, input forwardMessageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	fromPeer, err := services.ResolvePeer(tgCtx, input.FromPeer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve from_peer: %v", err)), nil
+	}
+
+	toPeer, err := services.ResolvePeer(tgCtx, input.ToPeer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve to_peer: %v", err)), nil
+	}
+
+	ids, err := parseMessageIDs(input.MessageIDs)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid message_ids: %v", err)), nil
+	}
+
+	randomIDs := make([]int64, len(ids))
+	for i := range randomIDs {
+		randomIDs[i] = randomID()
+	}
+
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		_, err := services.API().MessagesForwardMessages(ctx, &tg.MessagesForwardMessagesRequest{
+			FromPeer: fromPeer,
+			ToPeer:   toPeer,
+			ID:       ids,
+			RandomID: randomIDs,
+		})
+		return err
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to forward messages: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Forwarded %d message(s) successfully.", len(ids))), nil
 }
 
-func handleForwardMessage(_ context.Context, _ mcp.CallToolRequest, input forwardMessageInput) (*mcp.CallToolResult, error) {
+func handleCopyMessages(_ context.Context, _ mcp.CallToolRequest, input copyMessagesInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
 	fromPeer, err := services.ResolvePeer(tgCtx, input.FromPeer)
@@ -486,17 +1692,22 @@ func handleForwardMessage(_ context.Context, _ mcp.CallToolRequest, input forwar
 		randomIDs[i] = randomID()
 	}
 
-	_, err = services.API().MessagesForwardMessages(tgCtx, &tg.MessagesForwardMessagesRequest{
-		FromPeer: fromPeer,
-		ToPeer:   toPeer,
-		ID:       ids,
-		RandomID: randomIDs,
+	err = services.WithFloodRetry(tgCtx, func(ctx context.Context) error {
+		_, err := services.API().MessagesForwardMessages(ctx, &tg.MessagesForwardMessagesRequest{
+			FromPeer:          fromPeer,
+			ToPeer:            toPeer,
+			ID:                ids,
+			RandomID:          randomIDs,
+			DropAuthor:        true,
+			DropMediaCaptions: input.DropCaptions,
+		})
+		return err
 	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to forward messages: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("failed to copy messages: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Forwarded %d message(s) successfully.", len(ids))), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Copied %d message(s) successfully.", len(ids))), nil
 }
 
 func handleDeleteMessage(_ context.Context, _ mcp.CallToolRequest, input deleteMessageInput) (*mcp.CallToolResult, error) {
@@ -545,8 +1756,9 @@ func handleEditMessage(_ context.Context, _ mcp.CallToolRequest, input editMessa
 	}
 
 	editReq := &tg.MessagesEditMessageRequest{
-		Peer: peer,
-		ID:   input.MessageID,
+		Peer:      peer,
+		ID:        input.MessageID,
+		NoWebpage: input.NoWebpage,
 	}
 	editReq.SetMessage(input.Message)
 
@@ -578,6 +1790,147 @@ func handlePinMessage(_ context.Context, _ mcp.CallToolRequest, input pinMessage
 	return mcp.NewToolResultText("Message pinned successfully."), nil
 }
 
+func handleGetPinnedMessage(_ context.Context, _ mcp.CallToolRequest, input getPinnedMessageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	var pinnedID int
+	var hasPinned bool
+
+	switch p := peer.(type) {
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(tgCtx, &tg.InputChannel{
+			ChannelID:  p.ChannelID,
+			AccessHash: p.AccessHash,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get channel info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+		if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
+			pinnedID, hasPinned = full.GetPinnedMsgID()
+		}
+
+	case *tg.InputPeerChat:
+		fullResult, err := services.API().MessagesGetFullChat(tgCtx, p.ChatID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get chat info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+		if full, ok := fullResult.FullChat.(*tg.ChatFull); ok {
+			pinnedID, hasPinned = full.GetPinnedMsgID()
+		}
+
+	case *tg.InputPeerUser:
+		fullResult, err := services.API().UsersGetFullUser(tgCtx, &tg.InputUser{
+			UserID:     p.UserID,
+			AccessHash: p.AccessHash,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get user info: %v", err)), nil
+		}
+		services.StorePeers(tgCtx, fullResult.Chats, fullResult.Users)
+		pinnedID, hasPinned = fullResult.FullUser.GetPinnedMsgID()
+
+	default:
+		return mcp.NewToolResultError("unsupported peer type"), nil
+	}
+
+	if !hasPinned || pinnedID == 0 {
+		return mcp.NewToolResultText("No pinned message."), nil
+	}
+
+	msg, err := getMessageByID(tgCtx, peer, pinnedID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get pinned message: %v", err)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Pinned message:\n")
+	sb.WriteString(formatMessages(tgCtx, []tg.MessageClass{msg}))
+	if msg.Media != nil {
+		fmt.Fprintf(&sb, "Media: %s\n", mediaTypeName(msg.Media))
+		if doc, ok := msg.Media.(*tg.MessageMediaDocument); ok {
+			if d, ok := doc.Document.(*tg.Document); ok {
+				fmt.Fprintf(&sb, "MIME Type: %s\n", d.MimeType)
+				fmt.Fprintf(&sb, "Size: %s\n", formatSize(d.Size))
+			}
+		}
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetRecentLocations(_ context.Context, _ mcp.CallToolRequest, input getRecentLocationsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().MessagesGetRecentLocations(tgCtx, &tg.MessagesGetRecentLocationsRequest{
+		Peer:  peer,
+		Limit: limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get recent locations: %v", err)), nil
+	}
+
+	msgs := extractMessages(tgCtx, result)
+
+	var sb strings.Builder
+	count := 0
+	for _, mc := range msgs {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+
+		var geo tg.GeoPointClass
+		var details string
+		switch m := msg.Media.(type) {
+		case *tg.MessageMediaGeo:
+			geo = m.Geo
+		case *tg.MessageMediaGeoLive:
+			geo = m.Geo
+			details = fmt.Sprintf(" (live, %ds)", m.Period)
+		case *tg.MessageMediaVenue:
+			geo = m.Geo
+			details = fmt.Sprintf(" (venue: %s, %s)", m.Title, m.Address)
+		default:
+			continue
+		}
+
+		point, ok := geo.(*tg.GeoPoint)
+		if !ok {
+			continue
+		}
+
+		count++
+		t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+		fmt.Fprintf(&sb, "[%d] %s (%s): %.6f, %.6f%s\n", msg.ID, senderLabel(tgCtx, messageSender(msg)), t, point.Lat, point.Long, details)
+	}
+
+	if count == 0 {
+		return mcp.NewToolResultText("No recent locations found."), nil
+	}
+
+	header := fmt.Sprintf("Recent locations (%d):\n", count)
+	return mcp.NewToolResultText(header + sb.String()), nil
+}
+
 func handleSearchGlobal(_ context.Context, _ mcp.CallToolRequest, input searchGlobalInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -600,7 +1953,92 @@ func handleSearchGlobal(_ context.Context, _ mcp.CallToolRequest, input searchGl
 	}
 
 	msgs := extractMessages(tgCtx, result)
-	return mcp.NewToolResultText(formatMessages(msgs)), nil
+	return mcp.NewToolResultText(formatMessages(tgCtx, msgs)), nil
+}
+
+func handleSearchPosts(_ context.Context, _ mcp.CallToolRequest, input searchPostsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	hashtag := strings.TrimPrefix(strings.TrimSpace(input.Hashtag), "#")
+	if hashtag == "" {
+		return mcp.NewToolResultError("hashtag is required"), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := services.API().ChannelsSearchPosts(tgCtx, &tg.ChannelsSearchPostsRequest{
+		Hashtag:    hashtag,
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to search posts: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok || len(modified.GetMessages()) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No posts found for #%s.", hashtag)), nil
+	}
+	services.StorePeers(tgCtx, modified.GetChats(), modified.GetUsers())
+
+	channels := make(map[int64]*tg.Channel)
+	for _, c := range modified.GetChats() {
+		if ch, ok := c.(*tg.Channel); ok {
+			channels[ch.ID] = ch
+		}
+	}
+
+	// Group posts by channel, keeping channels in order of their best-ranked post
+	groups := make(map[int64][]*tg.Message)
+	var order []int64
+	total := 0
+	for _, mc := range modified.GetMessages() {
+		msg, ok := mc.(*tg.Message)
+		if !ok {
+			continue
+		}
+		id := peerToID(msg.PeerID)
+		if _, seen := groups[id]; !seen {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], msg)
+		total++
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Posts for #%s (%d in %d channels):\n", hashtag, total, len(order))
+	for _, id := range order {
+		ch := channels[id]
+		if ch != nil {
+			fmt.Fprintf(&sb, "\n== %s (ID: %d", ch.Title, ch.ID)
+			if ch.Username != "" {
+				fmt.Fprintf(&sb, ", @%s", ch.Username)
+			}
+			sb.WriteString(") ==\n")
+		} else {
+			fmt.Fprintf(&sb, "\n== Channel %d ==\n", id)
+		}
+
+		for _, msg := range groups[id] {
+			t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+			fmt.Fprintf(&sb, "[%d] (%s): %s", msg.ID, t, truncateText(msg.Message, 300))
+			if views, ok := msg.GetViews(); ok {
+				fmt.Fprintf(&sb, " [views: %d]", views)
+			}
+			if ch != nil && ch.Username != "" {
+				fmt.Fprintf(&sb, "\n  https://t.me/%s/%d", ch.Username, msg.ID)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
 }
 
 func handleReadHistory(_ context.Context, _ mcp.CallToolRequest, input readHistoryInput) (*mcp.CallToolResult, error) {
@@ -706,7 +2144,7 @@ func handleDeleteHistory(_ context.Context, _ mcp.CallToolRequest, input deleteH
 		revoke = *input.Revoke
 	}
 
-	_, err = services.API().MessagesDeleteHistory(tgCtx, &tg.MessagesDeleteHistoryRequest{
+	err = deleteHistoryFully(tgCtx, &tg.MessagesDeleteHistoryRequest{
 		Peer:   peer,
 		MaxID:  maxID,
 		Revoke: revoke,
@@ -747,7 +2185,302 @@ func handleTranslate(_ context.Context, _ mcp.CallToolRequest, input translateIn
 	if text == "" {
 		return mcp.NewToolResultText("No translation available."), nil
 	}
-	return mcp.NewToolResultText(text), nil
+
+	return mcp.NewToolResultText(fmt.Sprintf("Translation (%s): %s", input.ToLang, text)), nil
+}
+
+// uniqueScriptLanguages maps scripts used by essentially a single language to
+// its ISO 639-1 code, which is as far as script-based detection can go.
+var uniqueScriptLanguages = map[string]string{
+	"Hangul":   "ko",
+	"Japanese": "ja",
+	"Thai":     "th",
+	"Greek":    "el",
+	"Hebrew":   "he",
+	"Georgian": "ka",
+	"Armenian": "hy",
+}
+
+var detectableScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Arabic", unicode.Arabic},
+	{"Han", unicode.Han},
+	{"Japanese", unicode.Hiragana},
+	{"Japanese", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+	{"Thai", unicode.Thai},
+	{"Greek", unicode.Greek},
+	{"Hebrew", unicode.Hebrew},
+	{"Devanagari", unicode.Devanagari},
+	{"Georgian", unicode.Georgian},
+	{"Armenian", unicode.Armenian},
+}
+
+// unknownScript is reported for text whose letters are all in scripts outside detectableScripts.
+const unknownScript = "unknown"
+
+// detectScript returns the dominant writing script of text, unknownScript if none of its
+// letters are in a detectable script, or an empty string if it has no letters at all.
+// Any kana makes the text Japanese, since Japanese mixes kana with Han characters.
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, sc := range detectableScripts {
+			if unicode.Is(sc.table, r) {
+				counts[sc.name]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	if counts["Japanese"] > 0 {
+		return "Japanese"
+	}
+
+	best, bestCount := unknownScript, 0
+	for _, sc := range detectableScripts {
+		if counts[sc.name] > bestCount {
+			best, bestCount = sc.name, counts[sc.name]
+		}
+	}
+	return best
+}
+
+// sourceLanguageLabel describes the detected source language of text: the language
+// code when the script identifies it, otherwise the script name.
+func sourceLanguageLabel(text string) string {
+	script := detectScript(text)
+	switch script {
+	case "":
+		return ""
+	case unknownScript:
+		return "unknown script"
+	}
+	if lang, ok := uniqueScriptLanguages[script]; ok {
+		return lang
+	}
+	return script + " script"
+}
+
+// alreadyInLanguage reports whether text is known to be in lang without translating it.
+// Texts without letters (emoji, numbers, links) count as needing no translation; texts in
+// an unknown or shared script are never skipped.
+func alreadyInLanguage(text, lang string) bool {
+	script := detectScript(text)
+	if script == "" {
+		return true
+	}
+	detected, ok := uniqueScriptLanguages[script]
+	return ok && strings.EqualFold(detected, lang)
+}
+
+// translateBatchSize is the number of message IDs sent per MessagesTranslateText call.
+const translateBatchSize = 20
+
+func handleTranslateChat(_ context.Context, _ mcp.CallToolRequest, input translateChatInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	result, err := services.API().MessagesGetHistory(tgCtx, &tg.MessagesGetHistoryRequest{
+		Peer:  peer,
+		Limit: limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get history: %v", err)), nil
+	}
+
+	// Only messages with text can be translated; skip those already in the target language
+	var msgs []*tg.Message
+	skipped := 0
+	for _, mc := range extractMessages(tgCtx, result) {
+		msg, ok := mc.(*tg.Message)
+		if !ok || msg.Message == "" {
+			continue
+		}
+		if alreadyInLanguage(msg.Message, input.ToLang) {
+			skipped++
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		if skipped > 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No messages to translate (%d already in %s or without text).", skipped, input.ToLang)), nil
+		}
+		return mcp.NewToolResultText("No text messages to translate."), nil
+	}
+
+	translations := make(map[int]string, len(msgs))
+	for start := 0; start < len(msgs); start += translateBatchSize {
+		end := min(start+translateBatchSize, len(msgs))
+
+		ids := make([]int, 0, end-start)
+		for _, msg := range msgs[start:end] {
+			ids = append(ids, msg.ID)
+		}
+
+		req := &tg.MessagesTranslateTextRequest{
+			ToLang: input.ToLang,
+		}
+		req.SetPeer(peer)
+		req.SetID(ids)
+
+		translated, err := services.API().MessagesTranslateText(tgCtx, req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to translate messages: %v", err)), nil
+		}
+
+		// Results are returned in the same order as the requested IDs
+		for i, r := range translated.Result {
+			if i < len(ids) {
+				translations[ids[i]] = r.Text
+			}
+		}
+	}
+
+	names := make(map[senderKey]string)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Translated %d message(s) to %s", len(msgs), input.ToLang)
+	if skipped > 0 {
+		fmt.Fprintf(&sb, " (skipped %d already in %s or without text)", skipped, input.ToLang)
+	}
+	sb.WriteString(":\n")
+	for _, msg := range msgs {
+		t := time.Unix(int64(msg.Date), 0).UTC().Format("2006-01-02 15:04:05")
+
+		sender := cachedSenderLabel(tgCtx, names, msg)
+
+		fmt.Fprintf(&sb, "\n[%d] %s (%s)\n", msg.ID, sender, t)
+		if source := sourceLanguageLabel(msg.Message); source != "" {
+			fmt.Fprintf(&sb, "  Original (%s): %s\n", source, msg.Message)
+		} else {
+			fmt.Fprintf(&sb, "  Original: %s\n", msg.Message)
+		}
+		if text, ok := translations[msg.ID]; ok && strings.EqualFold(strings.TrimSpace(msg.Message), strings.TrimSpace(text)) {
+			fmt.Fprintf(&sb, "  Translation: (already in %s)\n", input.ToLang)
+		} else if ok {
+			fmt.Fprintf(&sb, "  Translation: %s\n", text)
+		} else {
+			sb.WriteString("  Translation: (not available)\n")
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetDiscussionMessage(_ context.Context, _ mcp.CallToolRequest, input getDiscussionMessageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	result, err := services.API().MessagesGetDiscussionMessage(tgCtx, &tg.MessagesGetDiscussionMessageRequest{
+		Peer:  peer,
+		MsgID: input.MessageID,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get discussion message: %v", err)), nil
+	}
+
+	services.StorePeers(tgCtx, result.Chats, result.Users)
+
+	// The thread starts at the oldest message, which is the discussion group's copy of the post
+	var discussion *tg.Message
+	for _, mc := range result.Messages {
+		if msg, ok := mc.(*tg.Message); ok && (discussion == nil || msg.ID < discussion.ID) {
+			discussion = msg
+		}
+	}
+	if discussion == nil {
+		return mcp.NewToolResultText("This post has no discussion message."), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Discussion group: %s (ID: %d)\n", senderLabel(tgCtx, discussion.PeerID), peerToID(discussion.PeerID))
+	fmt.Fprintf(&sb, "Discussion message ID: %d\n", discussion.ID)
+	if maxID, ok := result.GetMaxID(); ok {
+		fmt.Fprintf(&sb, "Latest comment ID: %d\n", maxID)
+	}
+	fmt.Fprintf(&sb, "Unread comments: %d\n", result.UnreadCount)
+	sb.WriteString("\nReply to the discussion message in the discussion group to post a comment.")
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetWebPage(_ context.Context, _ mcp.CallToolRequest, input getWebPageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	url := strings.TrimSpace(input.URL)
+	if url == "" {
+		return mcp.NewToolResultError("url is required"), nil
+	}
+
+	result, err := services.API().MessagesGetWebPagePreview(tgCtx, &tg.MessagesGetWebPagePreviewRequest{
+		Message: url,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get web page preview: %v", err)), nil
+	}
+
+	media, ok := result.Media.(*tg.MessageMediaWebPage)
+	if !ok {
+		return mcp.NewToolResultText("No preview available for this URL."), nil
+	}
+
+	switch page := media.Webpage.(type) {
+	case *tg.WebPage:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "URL: %s\n", page.URL)
+		if page.SiteName != "" {
+			fmt.Fprintf(&sb, "Site: %s\n", page.SiteName)
+		}
+		if page.Title != "" {
+			fmt.Fprintf(&sb, "Title: %s\n", page.Title)
+		}
+		if page.Description != "" {
+			fmt.Fprintf(&sb, "Description: %s\n", page.Description)
+		}
+		if page.Author != "" {
+			fmt.Fprintf(&sb, "Author: %s\n", page.Author)
+		}
+		if page.Type != "" {
+			fmt.Fprintf(&sb, "Type: %s\n", page.Type)
+		}
+		if page.Photo != nil {
+			sb.WriteString("Has photo: yes\n")
+		}
+		return mcp.NewToolResultText(sb.String()), nil
+	case *tg.WebPagePending:
+		return mcp.NewToolResultText("Preview is still being generated by Telegram, try again shortly."), nil
+	default:
+		return mcp.NewToolResultText("No preview available for this URL."), nil
+	}
 }
 
 func handleSendPoll(_ context.Context, _ mcp.CallToolRequest, input sendPollInput) (*mcp.CallToolResult, error) {
@@ -758,6 +2491,11 @@ func handleSendPoll(_ context.Context, _ mcp.CallToolRequest, input sendPollInpu
 		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
 	}
 
+	replyTo, err := messageReplyTo(peer, 0, input.TopicID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	optionParts := strings.Split(input.Options, ",")
 	if len(optionParts) < 2 {
 		return mcp.NewToolResultError("poll requires at least 2 options"), nil
@@ -793,11 +2531,16 @@ func handleSendPoll(_ context.Context, _ mcp.CallToolRequest, input sendPollInpu
 		media.SetCorrectAnswers([][]byte{{byte(input.CorrectOption)}})
 	}
 
-	_, err = services.API().MessagesSendMedia(tgCtx, &tg.MessagesSendMediaRequest{
+	req := &tg.MessagesSendMediaRequest{
 		Peer:     peer,
 		Media:    media,
 		RandomID: randomID(),
-	})
+	}
+	if replyTo != nil {
+		req.SetReplyTo(replyTo)
+	}
+
+	_, err = services.API().MessagesSendMedia(tgCtx, req)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to send poll: %v", err)), nil
 	}
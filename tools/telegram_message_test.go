@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/gotd/td/tg"
+)
+
+func TestRenderEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []tg.MessageEntityClass
+		want     string
+	}{
+		{
+			// 😀 is a surrogate pair, so "docs" starts at UTF-16 offset 11, not rune offset 10
+			name: "emoji before text url",
+			text: "Hi 😀 read docs now",
+			entities: []tg.MessageEntityClass{
+				&tg.MessageEntityTextURL{Offset: 11, Length: 4, URL: "https://example.com"},
+			},
+			want: "Hi 😀 read [docs](https://example.com) now",
+		},
+		{
+			name: "nested bold and italic",
+			text: "very important note",
+			entities: []tg.MessageEntityClass{
+				&tg.MessageEntityItalic{Offset: 0, Length: 4},
+				&tg.MessageEntityBold{Offset: 0, Length: 14},
+			},
+			want: "**_very_ important** note",
+		},
+		{
+			name: "out of range entity is skipped",
+			text: "short",
+			entities: []tg.MessageEntityClass{
+				&tg.MessageEntityBold{Offset: 3, Length: 10},
+				&tg.MessageEntityCode{Offset: 0, Length: 5},
+			},
+			want: "`short`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderEntities(tt.text, tt.entities); got != tt.want {
+				t.Errorf("renderEntities() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -48,6 +48,12 @@ func RegisterNotificationTools(s *server.MCPServer) {
 	)
 }
 
+// isMuted reports whether notification settings mute the peer right now.
+func isMuted(settings tg.PeerNotifySettings) bool {
+	muteUntil, ok := settings.GetMuteUntil()
+	return ok && int64(muteUntil) > time.Now().Unix()
+}
+
 func handleGetNotifySettings(_ context.Context, _ mcp.CallToolRequest, input getNotifySettingsInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
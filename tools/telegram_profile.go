@@ -23,6 +23,49 @@ type getReadParticipantsInput struct {
 	MessageID int    `json:"message_id" jsonschema:"required"`
 }
 
+type getAccountTTLInput struct{}
+
+type setAccountTTLInput struct {
+	Days int `json:"days" jsonschema:"required"`
+}
+
+type getAuthorizationsTTLInput struct{}
+
+type setAuthorizationsTTLInput struct {
+	Days int `json:"days" jsonschema:"required"`
+}
+
+type getContentSettingsInput struct{}
+
+type setContentSettingsInput struct {
+	SensitiveEnabled bool `json:"sensitive_enabled"`
+}
+
+type getLanguagesInput struct{}
+
+type getLanguageInput struct {
+	LangCode string `json:"lang_code"`
+}
+
+type getGlobalPrivacySettingsInput struct{}
+
+type setGlobalPrivacySettingsInput struct {
+	HideReadMarks                *bool `json:"hide_read_marks"`
+	ArchiveAndMuteNewNoncontacts *bool `json:"archive_and_mute_new_noncontacts"`
+	KeepArchivedUnmuted          *bool `json:"keep_archived_unmuted"`
+	KeepArchivedFolders          *bool `json:"keep_archived_folders"`
+	NewNoncontactsRequirePremium *bool `json:"new_noncontacts_require_premium"`
+}
+
+// Telegram accepts account self-destruct periods from one month to two years.
+const (
+	minAccountTTLDays = 30
+	maxAccountTTLDays = 730
+)
+
+// Inactive sessions can be kept for at most a year.
+const maxAuthorizationTTLDays = 365
+
 func RegisterProfileTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_update_profile",
@@ -46,6 +89,105 @@ func RegisterProfileTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleGetReadParticipants),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_account_ttl",
+			mcp.WithDescription("Get the account self-destruct period: the account is deleted if you stay offline this many days"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetAccountTTL),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_account_ttl",
+			mcp.WithDescription("Set the account self-destruct period: the account is deleted if you stay offline this many days"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("days", mcp.Required(), mcp.Description(fmt.Sprintf("Days of inactivity before the account is deleted (%d-%d, e.g. 30, 90, 180, 365, 730)", minAccountTTLDays, maxAccountTTLDays))),
+		),
+		mcp.NewTypedToolHandler(handleSetAccountTTL),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_authorizations_ttl",
+			mcp.WithDescription("Get how long inactive login sessions are kept before Telegram terminates them, and the number of active sessions"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetAuthorizationsTTL),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_authorizations_ttl",
+			mcp.WithDescription("Set how long inactive login sessions are kept before Telegram terminates them"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithNumber("days", mcp.Required(), mcp.Description(fmt.Sprintf("Days of inactivity before a session is terminated (1-%d, e.g. 7, 30, 90, 180)", maxAuthorizationTTLDays))),
+		),
+		mcp.NewTypedToolHandler(handleSetAuthorizationsTTL),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_content_settings",
+			mcp.WithDescription("Get whether sensitive content is shown, and whether the setting can be changed in your region"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetContentSettings),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_content_settings",
+			mcp.WithDescription("Show or hide sensitive content, such as media some channels hide behind a warning"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithBoolean("sensitive_enabled", mcp.Description("Show sensitive content (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleSetContentSettings),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_languages",
+			mcp.WithDescription("List the interface languages Telegram offers, marking the one this server uses. The language is set with the TELEGRAM_LANG_CODE environment variable"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetLanguages),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_language",
+			mcp.WithDescription("Get details of an interface language pack, such as its translation progress"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("lang_code", mcp.Description("Language code, e.g. de or pt-br (default: the language this server uses)")),
+		),
+		mcp.NewTypedToolHandler(handleGetLanguage),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_global_privacy_settings",
+			mcp.WithDescription("Get global privacy settings: hidden read times, auto-archiving of new chats from non-contacts, and archive behavior"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetGlobalPrivacySettings),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_global_privacy_settings",
+			mcp.WithDescription("Change global privacy settings. Only the options provided are changed"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithBoolean("hide_read_marks", mcp.Description("Hide your read times in private chats (you won't see others' either)")),
+			mcp.WithBoolean("archive_and_mute_new_noncontacts", mcp.Description("Automatically archive and mute new chats from non-contacts")),
+			mcp.WithBoolean("keep_archived_unmuted", mcp.Description("Keep unmuted archived chats in the archive when new messages arrive")),
+			mcp.WithBoolean("keep_archived_folders", mcp.Description("Keep archived chats from folders in the archive when new messages arrive")),
+			mcp.WithBoolean("new_noncontacts_require_premium", mcp.Description("Only allow non-contacts with Telegram Premium to message you (requires Premium)")),
+		),
+		mcp.NewTypedToolHandler(handleSetGlobalPrivacySettings),
+	)
 }
 
 func handleUpdateProfile(_ context.Context, _ mcp.CallToolRequest, input updateProfileInput) (*mcp.CallToolResult, error) {
@@ -120,3 +262,246 @@ func handleGetReadParticipants(_ context.Context, _ mcp.CallToolRequest, input g
 
 	return mcp.NewToolResultText(b.String()), nil
 }
+
+func handleGetAccountTTL(_ context.Context, _ mcp.CallToolRequest, _ getAccountTTLInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	ttl, err := services.API().AccountGetAccountTTL(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get account TTL: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Account self-destructs after %d days of inactivity.", ttl.Days)), nil
+}
+
+func handleSetAccountTTL(_ context.Context, _ mcp.CallToolRequest, input setAccountTTLInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	if input.Days < minAccountTTLDays || input.Days > maxAccountTTLDays {
+		return mcp.NewToolResultError(fmt.Sprintf("days must be between %d and %d", minAccountTTLDays, maxAccountTTLDays)), nil
+	}
+
+	_, err := services.API().AccountSetAccountTTL(tgCtx, tg.AccountDaysTTL{Days: input.Days})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set account TTL: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Account self-destruct period set to %d days.", input.Days)), nil
+}
+
+func handleGetAuthorizationsTTL(_ context.Context, _ mcp.CallToolRequest, _ getAuthorizationsTTLInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	auths, err := services.API().AccountGetAuthorizations(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get authorizations: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Inactive sessions are terminated after %d days.\nActive sessions: %d", auths.AuthorizationTTLDays, len(auths.Authorizations))), nil
+}
+
+func handleSetAuthorizationsTTL(_ context.Context, _ mcp.CallToolRequest, input setAuthorizationsTTLInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	if input.Days < 1 || input.Days > maxAuthorizationTTLDays {
+		return mcp.NewToolResultError(fmt.Sprintf("days must be between 1 and %d", maxAuthorizationTTLDays)), nil
+	}
+
+	_, err := services.API().AccountSetAuthorizationTTL(tgCtx, input.Days)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set authorizations TTL: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Inactive sessions will be terminated after %d days.", input.Days)), nil
+}
+
+func handleGetContentSettings(_ context.Context, _ mcp.CallToolRequest, _ getContentSettingsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	settings, err := services.API().AccountGetContentSettings(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get content settings: %v", err)), nil
+	}
+
+	var b strings.Builder
+	if settings.SensitiveEnabled {
+		b.WriteString("Sensitive content: shown\n")
+	} else {
+		b.WriteString("Sensitive content: hidden\n")
+	}
+	if settings.SensitiveCanChange {
+		b.WriteString("Can change: yes\n")
+	} else {
+		b.WriteString("Can change: no (locked in your region)\n")
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleSetContentSettings(_ context.Context, _ mcp.CallToolRequest, input setContentSettingsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	settings, err := services.API().AccountGetContentSettings(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get content settings: %v", err)), nil
+	}
+	if settings.SensitiveEnabled == input.SensitiveEnabled {
+		if input.SensitiveEnabled {
+			return mcp.NewToolResultText("Sensitive content is already shown."), nil
+		}
+		return mcp.NewToolResultText("Sensitive content is already hidden."), nil
+	}
+	if !settings.SensitiveCanChange {
+		return mcp.NewToolResultError("the sensitive content setting is locked in your region"), nil
+	}
+
+	_, err = services.API().AccountSetContentSettings(tgCtx, &tg.AccountSetContentSettingsRequest{
+		SensitiveEnabled: input.SensitiveEnabled,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set content settings: %v", err)), nil
+	}
+
+	if input.SensitiveEnabled {
+		return mcp.NewToolResultText("Sensitive content is now shown."), nil
+	}
+	return mcp.NewToolResultText("Sensitive content is now hidden."), nil
+}
+
+func handleGetLanguages(_ context.Context, _ mcp.CallToolRequest, _ getLanguagesInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	langs, err := services.API().LangpackGetLanguages(tgCtx, "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get languages: %v", err)), nil
+	}
+
+	current := services.LangCode()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Current language: %s\n\nAvailable languages (%d):\n", current, len(langs))
+	for _, l := range langs {
+		fmt.Fprintf(&b, "  %s: %s (%s)", l.LangCode, l.Name, l.NativeName)
+		if l.Beta {
+			b.WriteString(" [beta]")
+		}
+		if l.LangCode == current {
+			b.WriteString(" [current]")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nTo change the language, set TELEGRAM_LANG_CODE and restart the server.\n")
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetLanguage(_ context.Context, _ mcp.CallToolRequest, input getLanguageInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	langCode := input.LangCode
+	if langCode == "" {
+		langCode = services.LangCode()
+	}
+
+	lang, err := services.API().LangpackGetLanguage(tgCtx, &tg.LangpackGetLanguageRequest{
+		LangCode: langCode,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get language %q: %v", langCode, err)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Language: %s (%s)\n", lang.Name, lang.NativeName)
+	fmt.Fprintf(&b, "Code: %s\n", lang.LangCode)
+	if lang.BaseLangCode != "" {
+		fmt.Fprintf(&b, "Based on: %s\n", lang.BaseLangCode)
+	}
+	if lang.StringsCount > 0 {
+		fmt.Fprintf(&b, "Translated: %d of %d strings (%d%%)\n", lang.TranslatedCount, lang.StringsCount, lang.TranslatedCount*100/lang.StringsCount)
+	}
+	if lang.Official {
+		b.WriteString("Official: yes\n")
+	}
+	if lang.Beta {
+		b.WriteString("Beta: yes\n")
+	}
+	if lang.Rtl {
+		b.WriteString("Right-to-left: yes\n")
+	}
+	if lang.TranslationsURL != "" {
+		fmt.Fprintf(&b, "Translations: %s\n", lang.TranslationsURL)
+	}
+	if lang.LangCode == services.LangCode() {
+		b.WriteString("Current: yes\n")
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetGlobalPrivacySettings(_ context.Context, _ mcp.CallToolRequest, _ getGlobalPrivacySettingsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	settings, err := services.API().AccountGetGlobalPrivacySettings(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get global privacy settings: %v", err)), nil
+	}
+
+	var b strings.Builder
+	formatGlobalPrivacySettings(&b, settings)
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleSetGlobalPrivacySettings(_ context.Context, _ mcp.CallToolRequest, input setGlobalPrivacySettingsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	if input.HideReadMarks == nil && input.ArchiveAndMuteNewNoncontacts == nil && input.KeepArchivedUnmuted == nil &&
+		input.KeepArchivedFolders == nil && input.NewNoncontactsRequirePremium == nil {
+		return mcp.NewToolResultError("at least one setting must be provided"), nil
+	}
+
+	// Start from the current settings so options that aren't provided stay unchanged
+	settings, err := services.API().AccountGetGlobalPrivacySettings(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get global privacy settings: %v", err)), nil
+	}
+
+	if input.HideReadMarks != nil {
+		settings.HideReadMarks = *input.HideReadMarks
+	}
+	if input.ArchiveAndMuteNewNoncontacts != nil {
+		settings.ArchiveAndMuteNewNoncontactPeers = *input.ArchiveAndMuteNewNoncontacts
+	}
+	if input.KeepArchivedUnmuted != nil {
+		settings.KeepArchivedUnmuted = *input.KeepArchivedUnmuted
+	}
+	if input.KeepArchivedFolders != nil {
+		settings.KeepArchivedFolders = *input.KeepArchivedFolders
+	}
+	if input.NewNoncontactsRequirePremium != nil {
+		settings.NewNoncontactPeersRequirePremium = *input.NewNoncontactsRequirePremium
+	}
+
+	updated, err := services.API().AccountSetGlobalPrivacySettings(tgCtx, *settings)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set global privacy settings: %v", err)), nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Global privacy settings updated.\n")
+	formatGlobalPrivacySettings(&b, updated)
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func formatGlobalPrivacySettings(b *strings.Builder, s *tg.GlobalPrivacySettings) {
+	yesNo := func(v bool) string {
+		if v {
+			return "yes"
+		}
+		return "no"
+	}
+	fmt.Fprintf(b, "Hide read marks: %s\n", yesNo(s.HideReadMarks))
+	fmt.Fprintf(b, "Archive and mute new non-contacts: %s\n", yesNo(s.ArchiveAndMuteNewNoncontactPeers))
+	fmt.Fprintf(b, "Keep unmuted chats archived: %s\n", yesNo(s.KeepArchivedUnmuted))
+	fmt.Fprintf(b, "Keep folder chats archived: %s\n", yesNo(s.KeepArchivedFolders))
+	fmt.Fprintf(b, "New non-contacts require Premium: %s\n", yesNo(s.NewNoncontactPeersRequirePremium))
+}
@@ -50,7 +50,7 @@ func handleDailyDigest(_ context.Context, _ mcp.GetPromptRequest) (*mcp.GetPromp
 					Type: "text",
 					Text: `Create a daily digest of my unread Telegram messages:
 
-1. Call telegram_get_unread to fetch all unread conversations
+1. Call telegram_get_unread with skip_muted=true to fetch unread conversations, ignoring muted chats
 2. Group the results by priority:
    - URGENT: Direct messages (DMs) with multiple unread messages
    - IMPORTANT: Group chats where I was mentioned or replied to
@@ -78,19 +78,20 @@ func handleCommunityManager(_ context.Context, request mcp.GetPromptRequest) (*m
 					Text: fmt.Sprintf(`Help me manage the Telegram community %s:
 
 1. Call telegram_chat_context with peer="%s" to get the full community snapshot
+   and telegram_find_unanswered_questions with peer="%s" to spot questions nobody has answered
 2. Analyze recent messages and identify:
    - Unanswered questions from members
-   - Spam or off-topic messages that should be removed
+   - Spam or off-topic messages that should be removed (telegram_detect_spam_candidates with peer="%s" flags likely spam)
    - Active discussions that might need moderation
-   - New members who should be welcomed
+   - New members who should be welcomed (telegram_get_new_members with peer="%s" lists recent joins)
 3. Suggest specific actions:
    - Draft responses to unanswered questions
    - List messages to delete (with message IDs)
    - Identify members to warn or ban
 4. Provide engagement insights:
    - Most active members
-   - Peak activity times
-   - Trending topics`, peer, peer),
+   - Peak activity times (call telegram_get_chat_timeline with peer="%s")
+   - Trending topics`, peer, peer, peer, peer, peer, peer),
 				},
 			},
 		},
@@ -13,9 +13,11 @@ import (
 )
 
 type sendReactionInput struct {
-	Peer      string `json:"peer" jsonschema:"required"`
-	MessageID int    `json:"message_id" jsonschema:"required"`
-	Reaction  string `json:"reaction" jsonschema:"required"`
+	Peer        string `json:"peer" jsonschema:"required"`
+	MessageID   int    `json:"message_id" jsonschema:"required"`
+	Reaction    string `json:"reaction" jsonschema:"required"`
+	Big         bool   `json:"big"`
+	AddToRecent *bool  `json:"add_to_recent"`
 }
 
 type getMessageReactionsInput struct {
@@ -23,15 +25,194 @@ type getMessageReactionsInput struct {
 	MessageID int    `json:"message_id" jsonschema:"required"`
 }
 
+type getDefaultReactionInput struct{}
+
+type setDefaultReactionInput struct {
+	Reaction string `json:"reaction" jsonschema:"required"`
+}
+
+type getEmojiKeywordsInput struct {
+	Keyword  string `json:"keyword" jsonschema:"required"`
+	LangCode string `json:"lang_code"`
+}
+
+// reactionAliases maps common reaction names (as LLMs and chat apps write them)
+// to the emoji Telegram expects.
+var reactionAliases = map[string]string{
+	"thumbsup":       "👍",
+	"+1":             "👍",
+	"like":           "👍",
+	"thumbsdown":     "👎",
+	"-1":             "👎",
+	"dislike":        "👎",
+	"heart":          "❤",
+	"love":           "❤",
+	"red_heart":      "❤",
+	"fire":           "🔥",
+	"smiling_hearts": "🥰",
+	"clap":           "👏",
+	"grin":           "😁",
+	"thinking":       "🤔",
+	"mind_blown":     "🤯",
+	"exploding_head": "🤯",
+	"scream":         "😱",
+	"rage":           "🤬",
+	"cry":            "😢",
+	"tada":           "🎉",
+	"party":          "🎉",
+	"star_struck":    "🤩",
+	"vomit":          "🤮",
+	"poop":           "💩",
+	"pray":           "🙏",
+	"ok_hand":        "👌",
+	"ok":             "👌",
+	"dove":           "🕊",
+	"clown":          "🤡",
+	"yawn":           "🥱",
+	"woozy":          "🥴",
+	"heart_eyes":     "😍",
+	"whale":          "🐳",
+	"heart_on_fire":  "❤‍🔥",
+	"new_moon_face":  "🌚",
+	"hotdog":         "🌭",
+	"100":            "💯",
+	"rofl":           "🤣",
+	"joy":            "🤣",
+	"zap":            "⚡",
+	"lightning":      "⚡",
+	"banana":         "🍌",
+	"trophy":         "🏆",
+	"broken_heart":   "💔",
+	"raised_eyebrow": "🤨",
+	"neutral_face":   "😐",
+	"strawberry":     "🍓",
+	"champagne":      "🍾",
+	"kiss":           "💋",
+	"smiling_imp":    "😈",
+	"sleeping":       "😴",
+	"sob":            "😭",
+	"nerd":           "🤓",
+	"ghost":          "👻",
+	"technologist":   "👨‍💻",
+	"eyes":           "👀",
+	"jack_o_lantern": "🎃",
+	"see_no_evil":    "🙈",
+	"innocent":       "😇",
+	"fearful":        "😨",
+	"handshake":      "🤝",
+	"writing_hand":   "✍",
+	"hugs":           "🤗",
+	"salute":         "🫡",
+	"santa":          "🎅",
+	"christmas_tree": "🎄",
+	"snowman":        "☃",
+	"nail_care":      "💅",
+	"zany":           "🤪",
+	"moai":           "🗿",
+	"cool":           "🆒",
+	"cupid":          "💘",
+	"hear_no_evil":   "🙉",
+	"unicorn":        "🦄",
+	"kissing_heart":  "😘",
+	"pill":           "💊",
+	"speak_no_evil":  "🙊",
+	"sunglasses":     "😎",
+	"space_invader":  "👾",
+	"shrug":          "🤷",
+	"angry":          "😡",
+}
+
+// normalizeReaction converts a reaction name like ":thumbsup:" to its emoji.
+// Emoji and unknown values are returned unchanged.
+func normalizeReaction(s string) string {
+	name := strings.ToLower(strings.Trim(strings.TrimSpace(s), ":"))
+	name = strings.ReplaceAll(name, " ", "_")
+	if emoji, ok := reactionAliases[name]; ok {
+		return emoji
+	}
+	return strings.TrimSpace(s)
+}
+
+// checkReactionAllowed validates an emoji reaction against the chat's available
+// reactions. Private chats and chats whose settings can't be fetched are not checked.
+func checkReactionAllowed(ctx context.Context, peer tg.InputPeerClass, emoticon string) error {
+	var available tg.ChatReactionsClass
+
+	switch p := peer.(type) {
+	case *tg.InputPeerChannel:
+		fullResult, err := services.API().ChannelsGetFullChannel(ctx, &tg.InputChannel{
+			ChannelID:  p.ChannelID,
+			AccessHash: p.AccessHash,
+		})
+		if err != nil {
+			return nil
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChannelFull); ok {
+			available, _ = full.GetAvailableReactions()
+		}
+	case *tg.InputPeerChat:
+		fullResult, err := services.API().MessagesGetFullChat(ctx, p.ChatID)
+		if err != nil {
+			return nil
+		}
+		if full, ok := fullResult.FullChat.(*tg.ChatFull); ok {
+			available, _ = full.GetAvailableReactions()
+		}
+	default:
+		return nil
+	}
+
+	switch r := available.(type) {
+	case *tg.ChatReactionsNone:
+		return fmt.Errorf("reactions are disabled in this chat")
+	case *tg.ChatReactionsSome:
+		allowed := make([]string, 0, len(r.Reactions))
+		for _, rc := range r.Reactions {
+			if e, ok := rc.(*tg.ReactionEmoji); ok {
+				if e.Emoticon == emoticon {
+					return nil
+				}
+				allowed = append(allowed, e.Emoticon)
+			}
+		}
+		return fmt.Errorf("reaction %s is not allowed in this chat (allowed: %s)", emoticon, strings.Join(allowed, " "))
+	}
+	return nil
+}
+
+// parseReaction converts a normalized reaction string to a reaction: numeric
+// strings are custom emoji document IDs, anything else is an emoji.
+func parseReaction(s string) tg.ReactionClass {
+	if docID, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return &tg.ReactionCustomEmoji{DocumentID: docID}
+	}
+	return &tg.ReactionEmoji{Emoticon: s}
+}
+
+func reactionLabel(r tg.ReactionClass) string {
+	switch r := r.(type) {
+	case *tg.ReactionEmoji:
+		return r.Emoticon
+	case *tg.ReactionCustomEmoji:
+		return fmt.Sprintf("[custom:%d]", r.DocumentID)
+	case *tg.ReactionPaid:
+		return "[paid]"
+	default:
+		return "[unknown]"
+	}
+}
+
 func RegisterReactionTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_send_reaction",
-			mcp.WithDescription("Send a reaction to a message. Use an emoji like '👍' or a custom emoji document ID. Send empty string to remove reaction."),
+			mcp.WithDescription("Send a reaction to a message. Use an emoji like '👍', a name like ':thumbsup:', or a custom emoji document ID. Send empty string to remove reaction."),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username")),
 			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message to react to")),
-			mcp.WithString("reaction", mcp.Required(), mcp.Description("Emoji like '👍' or custom emoji document ID. Empty string to remove reaction.")),
+			mcp.WithString("reaction", mcp.Required(), mcp.Description("Emoji like '👍', name like ':thumbsup:' or 'fire', or custom emoji document ID. Empty string to remove reaction.")),
+			mcp.WithBoolean("big", mcp.Description("Play the large reaction animation (default false)")),
+			mcp.WithBoolean("add_to_recent", mcp.Description("Add the reaction to the recently used reactions list (default false)")),
 		),
 		mcp.NewTypedToolHandler(handleSendReaction),
 	)
@@ -46,6 +227,36 @@ func RegisterReactionTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleGetMessageReactions),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_default_reaction",
+			mcp.WithDescription("Get your default reaction, sent when you double-tap a message"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+		),
+		mcp.NewTypedToolHandler(handleGetDefaultReaction),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_set_default_reaction",
+			mcp.WithDescription("Set your default reaction, sent when you double-tap a message"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("reaction", mcp.Required(), mcp.Description("Emoji like '👍', name like ':thumbsup:' or 'fire', or custom emoji document ID")),
+		),
+		mcp.NewTypedToolHandler(handleSetDefaultReaction),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_emoji_keywords",
+			mcp.WithDescription("Find emoji for a keyword in a given language, using Telegram's localized emoji keywords"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("keyword", mcp.Required(), mcp.Description("Keyword to look up, e.g. 'cat' or 'gato'")),
+			mcp.WithString("lang_code", mcp.Description("Language code of the keyword, e.g. en, es, de (default en)")),
+		),
+		mcp.NewTypedToolHandler(handleGetEmojiKeywords),
+	)
 }
 
 func handleSendReaction(_ context.Context, _ mcp.CallToolRequest, input sendReactionInput) (*mcp.CallToolResult, error) {
@@ -59,16 +270,20 @@ func handleSendReaction(_ context.Context, _ mcp.CallToolRequest, input sendReac
 	req := &tg.MessagesSendReactionRequest{
 		Peer:  peer,
 		MsgID: input.MessageID,
+		Big:   input.Big,
+	}
+	if input.AddToRecent != nil {
+		req.AddToRecent = *input.AddToRecent
 	}
 
-	if input.Reaction != "" {
-		var reaction tg.ReactionClass
+	reactionStr := normalizeReaction(input.Reaction)
 
-		// If the reaction is a numeric string, treat it as a custom emoji document ID
-		if docID, parseErr := strconv.ParseInt(input.Reaction, 10, 64); parseErr == nil {
-			reaction = &tg.ReactionCustomEmoji{DocumentID: docID}
-		} else {
-			reaction = &tg.ReactionEmoji{Emoticon: input.Reaction}
+	if reactionStr != "" {
+		reaction := parseReaction(reactionStr)
+		if _, ok := reaction.(*tg.ReactionEmoji); ok {
+			if err := checkReactionAllowed(tgCtx, peer, reactionStr); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 		}
 
 		req.SetReaction([]tg.ReactionClass{reaction})
@@ -81,10 +296,10 @@ func handleSendReaction(_ context.Context, _ mcp.CallToolRequest, input sendReac
 		return mcp.NewToolResultError(fmt.Sprintf("failed to send reaction: %v", err)), nil
 	}
 
-	if input.Reaction == "" {
+	if reactionStr == "" {
 		return mcp.NewToolResultText("Reaction removed successfully."), nil
 	}
-	return mcp.NewToolResultText(fmt.Sprintf("Reaction %s sent successfully.", input.Reaction)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Reaction %s sent successfully.", reactionStr)), nil
 }
 
 func handleGetMessageReactions(_ context.Context, _ mcp.CallToolRequest, input getMessageReactionsInput) (*mcp.CallToolResult, error) {
@@ -108,16 +323,88 @@ func handleGetMessageReactions(_ context.Context, _ mcp.CallToolRequest, input g
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Reactions for message %d:\n", input.MessageID)
 	for _, rc := range reactions.Results {
-		switch r := rc.Reaction.(type) {
-		case *tg.ReactionEmoji:
-			fmt.Fprintf(&sb, "  %s: %d\n", r.Emoticon, rc.Count)
-		case *tg.ReactionCustomEmoji:
-			fmt.Fprintf(&sb, "  [custom:%d]: %d\n", r.DocumentID, rc.Count)
-		case *tg.ReactionPaid:
-			fmt.Fprintf(&sb, "  [paid]: %d\n", rc.Count)
-		default:
-			fmt.Fprintf(&sb, "  [unknown]: %d\n", rc.Count)
+		fmt.Fprintf(&sb, "  %s: %d\n", reactionLabel(rc.Reaction), rc.Count)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func handleGetDefaultReaction(_ context.Context, _ mcp.CallToolRequest, _ getDefaultReactionInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	config, err := services.API().HelpGetConfig(tgCtx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get config: %v", err)), nil
+	}
+
+	reaction, ok := config.GetReactionsDefault()
+	if !ok {
+		return mcp.NewToolResultText("No default reaction set."), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Default reaction: %s", reactionLabel(reaction))), nil
+}
+
+func handleSetDefaultReaction(_ context.Context, _ mcp.CallToolRequest, input setDefaultReactionInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	reactionStr := normalizeReaction(input.Reaction)
+	if reactionStr == "" {
+		return mcp.NewToolResultError("reaction is required"), nil
+	}
+	reaction := parseReaction(reactionStr)
+
+	_, err := services.API().MessagesSetDefaultReaction(tgCtx, reaction)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set default reaction: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Default reaction set to %s.", reactionLabel(reaction))), nil
+}
+
+func handleGetEmojiKeywords(_ context.Context, _ mcp.CallToolRequest, input getEmojiKeywordsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	keyword := strings.ToLower(strings.TrimSpace(input.Keyword))
+	if keyword == "" {
+		return mcp.NewToolResultError("keyword is required"), nil
+	}
+	langCode := input.LangCode
+	if langCode == "" {
+		langCode = "en"
+	}
+
+	result, err := services.API().MessagesGetEmojiKeywords(tgCtx, langCode)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get emoji keywords: %v", err)), nil
+	}
+
+	// Exact matches first, then keywords starting with the query
+	var exact, prefix []*tg.EmojiKeyword
+	for _, kc := range result.Keywords {
+		kw, ok := kc.(*tg.EmojiKeyword)
+		if !ok {
+			continue
 		}
+		switch k := strings.ToLower(kw.Keyword); {
+		case k == keyword:
+			exact = append(exact, kw)
+		case strings.HasPrefix(k, keyword):
+			prefix = append(prefix, kw)
+		}
+	}
+
+	matches := append(exact, prefix...)
+	if len(matches) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No emoji found for %q (%s).", input.Keyword, langCode)), nil
+	}
+	if len(matches) > 20 {
+		matches = matches[:20]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Emoji for %q (%s):\n", input.Keyword, langCode)
+	for _, kw := range matches {
+		fmt.Fprintf(&sb, "  %s: %s\n", kw.Keyword, strings.Join(kw.Emoticons, " "))
 	}
 
 	return mcp.NewToolResultText(sb.String()), nil
@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gotd/td/tg"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nguyenvanduocit/telegram-mcp/services"
+)
+
+type getStickersForEmojiInput struct {
+	Emoji string `json:"emoji" jsonschema:"required"`
+	Limit int    `json:"limit"`
+}
+
+type getFavedStickersInput struct {
+	IncludeRecent bool `json:"include_recent"`
+}
+
+type installStickerSetInput struct {
+	ShortName string `json:"short_name" jsonschema:"required"`
+}
+
+type saveGifInput struct {
+	Peer      string `json:"peer" jsonschema:"required"`
+	MessageID int    `json:"message_id" jsonschema:"required"`
+	Unsave    bool   `json:"unsave"`
+}
+
+func RegisterStickerTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool("telegram_get_stickers_for_emoji",
+			mcp.WithDescription("Find stickers associated with an emoji, returning document IDs and sticker set names"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("emoji", mcp.Required(), mcp.Description("Emoji to find stickers for, e.g. '😂' or a name like 'joy'")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of stickers to return (default 20, max 50)")),
+		),
+		mcp.NewTypedToolHandler(handleGetStickersForEmoji),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_get_faved_stickers",
+			mcp.WithDescription("Get your favorite stickers, and optionally recently used stickers, with document IDs"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithBoolean("include_recent", mcp.Description("Also list recently used stickers (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleGetFavedStickers),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_install_sticker_set",
+			mcp.WithDescription("Add a sticker set to your account by its short name"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("short_name", mcp.Required(), mcp.Description("Sticker set short name, or a t.me/addstickers/ link")),
+		),
+		mcp.NewTypedToolHandler(handleInstallStickerSet),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_save_gif",
+			mcp.WithDescription("Save a GIF from a message to your saved GIFs, or remove it"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("peer", mcp.Required(), mcp.Description("Chat ID or @username containing the GIF")),
+			mcp.WithNumber("message_id", mcp.Required(), mcp.Description("ID of the message with the GIF")),
+			mcp.WithBoolean("unsave", mcp.Description("Remove the GIF from saved GIFs instead (default false)")),
+		),
+		mcp.NewTypedToolHandler(handleSaveGif),
+	)
+}
+
+func handleGetStickersForEmoji(_ context.Context, _ mcp.CallToolRequest, input getStickersForEmojiInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	emoji := normalizeReaction(input.Emoji)
+	if emoji == "" {
+		return mcp.NewToolResultError("emoji is required"), nil
+	}
+
+	result, err := services.API().MessagesGetStickers(tgCtx, &tg.MessagesGetStickersRequest{
+		Emoticon: emoji,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get stickers: %v", err)), nil
+	}
+
+	stickers, ok := result.(*tg.MessagesStickers)
+	if !ok || len(stickers.Stickers) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No stickers found for %s.", emoji)), nil
+	}
+
+	docs := stickers.Stickers
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Stickers for %s (%d of %d):\n", emoji, len(docs), len(stickers.Stickers))
+	formatStickers(tgCtx, &b, docs)
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleGetFavedStickers(_ context.Context, _ mcp.CallToolRequest, input getFavedStickersInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	faved, err := services.API().MessagesGetFavedStickers(tgCtx, 0)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get favorite stickers: %v", err)), nil
+	}
+
+	var b strings.Builder
+	if f, ok := faved.(*tg.MessagesFavedStickers); ok && len(f.Stickers) > 0 {
+		fmt.Fprintf(&b, "Favorite stickers (%d):", len(f.Stickers))
+		formatStickers(tgCtx, &b, f.Stickers)
+	} else {
+		b.WriteString("No favorite stickers.\n")
+	}
+
+	if input.IncludeRecent {
+		recent, err := services.API().MessagesGetRecentStickers(tgCtx, &tg.MessagesGetRecentStickersRequest{})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to get recent stickers: %v", err)), nil
+		}
+
+		b.WriteString("\n")
+		if r, ok := recent.(*tg.MessagesRecentStickers); ok && len(r.Stickers) > 0 {
+			fmt.Fprintf(&b, "Recent stickers (%d):", len(r.Stickers))
+			formatStickers(tgCtx, &b, r.Stickers)
+		} else {
+			b.WriteString("No recent stickers.\n")
+		}
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleInstallStickerSet(_ context.Context, _ mcp.CallToolRequest, input installStickerSetInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	shortName := strings.TrimSpace(input.ShortName)
+	if i := strings.LastIndex(shortName, "/addstickers/"); i >= 0 {
+		shortName = shortName[i+len("/addstickers/"):]
+	}
+	if shortName == "" {
+		return mcp.NewToolResultError("short_name is required"), nil
+	}
+
+	set := &tg.InputStickerSetShortName{ShortName: shortName}
+
+	result, err := services.API().MessagesGetStickerSet(tgCtx, &tg.MessagesGetStickerSetRequest{
+		Stickerset: set,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get sticker set: %v", err)), nil
+	}
+	full, ok := result.(*tg.MessagesStickerSet)
+	if !ok {
+		return mcp.NewToolResultError("sticker set not found"), nil
+	}
+	if full.Set.InstalledDate != 0 && !full.Set.Archived {
+		return mcp.NewToolResultText(fmt.Sprintf("Sticker set %q is already installed.", full.Set.Title)), nil
+	}
+
+	installResult, err := services.API().MessagesInstallStickerSet(tgCtx, &tg.MessagesInstallStickerSetRequest{
+		Stickerset: set,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to install sticker set: %v", err)), nil
+	}
+
+	msg := fmt.Sprintf("Sticker set %q (%d stickers) installed successfully.", full.Set.Title, full.Set.Count)
+	if archive, ok := installResult.(*tg.MessagesStickerSetInstallResultArchive); ok && len(archive.Sets) > 0 {
+		msg += fmt.Sprintf(" %d older set(s) were archived to make room.", len(archive.Sets))
+	}
+	return mcp.NewToolResultText(msg), nil
+}
+
+func handleSaveGif(_ context.Context, _ mcp.CallToolRequest, input saveGifInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	peer, err := services.ResolvePeer(tgCtx, input.Peer)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	msg, err := getMessageByID(tgCtx, peer, input.MessageID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get message: %v", err)), nil
+	}
+
+	media, ok := msg.Media.(*tg.MessageMediaDocument)
+	if !ok {
+		return mcp.NewToolResultError("message does not contain a GIF"), nil
+	}
+	doc, ok := media.Document.AsNotEmpty()
+	if !ok || !isAnimatedDocument(doc) {
+		return mcp.NewToolResultError("message does not contain a GIF"), nil
+	}
+
+	saved, err := isSavedGif(tgCtx, doc.ID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get saved GIFs: %v", err)), nil
+	}
+	if saved && !input.Unsave {
+		return mcp.NewToolResultText("GIF is already saved."), nil
+	}
+	if !saved && input.Unsave {
+		return mcp.NewToolResultText("GIF is not in saved GIFs."), nil
+	}
+
+	_, err = services.API().MessagesSaveGif(tgCtx, &tg.MessagesSaveGifRequest{
+		ID:     doc.AsInput(),
+		Unsave: input.Unsave,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to save GIF: %v", err)), nil
+	}
+
+	if input.Unsave {
+		return mcp.NewToolResultText("GIF removed from saved GIFs."), nil
+	}
+	return mcp.NewToolResultText("GIF saved successfully."), nil
+}
+
+func isAnimatedDocument(doc *tg.Document) bool {
+	for _, attr := range doc.Attributes {
+		if _, ok := attr.(*tg.DocumentAttributeAnimated); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isSavedGif(ctx context.Context, docID int64) (bool, error) {
+	result, err := services.API().MessagesGetSavedGifs(ctx, 0)
+	if err != nil {
+		return false, err
+	}
+	gifs, ok := result.(*tg.MessagesSavedGifs)
+	if !ok {
+		return false, nil
+	}
+	for _, g := range gifs.Gifs {
+		if g.GetID() == docID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// formatStickers writes one line per sticker document with its ID, emoji and set name.
+// Set names are looked up once per set, since documents only carry the set ID.
+func formatStickers(ctx context.Context, b *strings.Builder, docs []tg.DocumentClass) {
+	setNames := make(map[int64]string)
+
+	for _, dc := range docs {
+		doc, ok := dc.AsNotEmpty()
+		if !ok {
+			continue
+		}
+
+		var alt, setName string
+		for _, attr := range doc.Attributes {
+			sticker, ok := attr.(*tg.DocumentAttributeSticker)
+			if !ok {
+				continue
+			}
+			alt = sticker.Alt
+			if set, ok := sticker.Stickerset.(*tg.InputStickerSetID); ok {
+				name, cached := setNames[set.ID]
+				if !cached {
+					name = stickerSetName(ctx, set)
+					setNames[set.ID] = name
+				}
+				setName = name
+			}
+		}
+
+		fmt.Fprintf(b, "\n  Document ID: %d", doc.ID)
+		if alt != "" {
+			fmt.Fprintf(b, ", Emoji: %s", alt)
+		}
+		if setName != "" {
+			fmt.Fprintf(b, ", Set: %s", setName)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// stickerSetName returns "Title (short_name)" for a sticker set, or an empty string if it can't be fetched.
+func stickerSetName(ctx context.Context, set tg.InputStickerSetClass) string {
+	result, err := services.API().MessagesGetStickerSet(ctx, &tg.MessagesGetStickerSetRequest{
+		Stickerset: set,
+	})
+	if err != nil {
+		return ""
+	}
+	full, ok := result.(*tg.MessagesStickerSet)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", full.Set.Title, full.Set.ShortName)
+}
@@ -176,21 +176,21 @@ func handleSendStory(_ context.Context, _ mcp.CallToolRequest, input sendStoryIn
 		return mcp.NewToolResultError(fmt.Sprintf("file not found: %v", err)), nil
 	}
 
-	u := uploader.NewUploader(services.API())
+	u := uploader.NewUploader(services.API()).WithThreads(transferThreads(0))
 	uploaded, err := u.FromPath(tgCtx, cleanPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to upload file: %v", err)), nil
 	}
 
 	var media tg.InputMediaClass
-	ext := strings.ToLower(filepath.Ext(cleanPath))
-	switch ext {
-	case ".jpg", ".jpeg", ".png", ".webp":
+	mimeType := mimeFromPath(cleanPath)
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/webp":
 		media = &tg.InputMediaUploadedPhoto{File: uploaded}
 	default:
 		media = &tg.InputMediaUploadedDocument{
 			File:     uploaded,
-			MimeType: mimeFromPath(cleanPath),
+			MimeType: mimeType,
 			Attributes: []tg.DocumentAttributeClass{
 				&tg.DocumentAttributeVideo{},
 			},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gotd/td/tg"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -26,10 +27,20 @@ type searchContactsInput struct {
 	Limit int    `json:"limit"`
 }
 
+type searchContactsLocalInput struct {
+	Query string `json:"query" jsonschema:"required"`
+	Limit int    `json:"limit"`
+}
+
+type findUserInChatsInput struct {
+	UserID string `json:"user_id" jsonschema:"required"`
+	Limit  int    `json:"limit"`
+}
+
 func RegisterUserTools(s *server.MCPServer) {
 	s.AddTool(
 		mcp.NewTool("telegram_get_me",
-			mcp.WithDescription("Get information about the currently logged-in Telegram user"),
+			mcp.WithDescription("Get information about the currently logged-in Telegram user, including Premium status, two-step verification and account limits"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 		),
@@ -77,6 +88,38 @@ func RegisterUserTools(s *server.MCPServer) {
 		),
 		mcp.NewTypedToolHandler(handleSearchContacts),
 	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_search_contacts_local",
+			mcp.WithDescription("Search users and chats by name or username in both Telegram's server search and the local peer cache, so chats you've talked to but that server search misses are still found"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("query",
+				mcp.Description("Name, title or username substring"),
+				mcp.Required(),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of results from each source (default 20, max 100)"),
+			),
+		),
+		mcp.NewTypedToolHandler(handleSearchContactsLocal),
+	)
+
+	s.AddTool(
+		mcp.NewTool("telegram_find_user_in_chats",
+			mcp.WithDescription("Find which of your groups and channels a user is a member of, by scanning your recent dialogs"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("user_id",
+				mcp.Description("User ID (numeric) or @username"),
+				mcp.Required(),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of recent dialogs to scan (default 50, max 100)"),
+			),
+		),
+		mcp.NewTypedToolHandler(handleFindUserInChats),
+	)
 }
 
 func handleGetMe(_ context.Context, _ mcp.CallToolRequest, input getMeInput) (*mcp.CallToolResult, error) {
@@ -112,10 +155,82 @@ func handleGetMe(_ context.Context, _ mcp.CallToolRequest, input getMeInput) (*m
 	if fullResult.FullUser.About != "" {
 		fmt.Fprintf(&b, "\nBio: %s", fullResult.FullUser.About)
 	}
+	if self.Premium {
+		b.WriteString("\nPremium: yes")
+	} else {
+		b.WriteString("\nPremium: no")
+	}
+
+	if password, err := services.API().AccountGetPassword(tgCtx); err == nil {
+		if password.HasPassword {
+			b.WriteString("\nTwo-step verification: enabled")
+		} else {
+			b.WriteString("\nTwo-step verification: disabled")
+		}
+	}
+
+	// Limits differ between free and Premium accounts; show the ones that apply
+	if appConfig, err := services.API().HelpGetAppConfig(tgCtx, 0); err == nil {
+		if cfg, ok := appConfig.(*tg.HelpAppConfig); ok {
+			writeAccountLimits(&b, cfg.Config, self.Premium)
+		}
+	}
 
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+// accountLimits are the app config limits shown by telegram_get_me, by key prefix.
+var accountLimits = []struct {
+	key   string
+	label string
+}{
+	{"channels_limit", "Groups and channels joined"},
+	{"dialog_filters_limit", "Folders"},
+	{"dialog_filters_chats_limit", "Chats per folder"},
+	{"dialogs_pinned_limit", "Pinned chats"},
+	{"saved_gifs_limit", "Saved GIFs"},
+	{"stickers_faved_limit", "Favorite stickers"},
+	{"caption_length_limit", "Caption length"},
+	{"about_length_limit", "Bio length"},
+	{"upload_max_fileparts", "Upload size (MB)"},
+}
+
+func writeAccountLimits(b *strings.Builder, config tg.JSONValueClass, premium bool) {
+	obj, ok := config.(*tg.JSONObject)
+	if !ok {
+		return
+	}
+
+	values := make(map[string]float64, len(obj.Value))
+	for _, v := range obj.Value {
+		if n, ok := v.Value.(*tg.JSONNumber); ok {
+			values[v.Key] = n.Value
+		}
+	}
+
+	suffix := "_default"
+	if premium {
+		suffix = "_premium"
+	}
+
+	header := false
+	for _, l := range accountLimits {
+		v, ok := values[l.key+suffix]
+		if !ok {
+			continue
+		}
+		if !header {
+			b.WriteString("\nLimits:")
+			header = true
+		}
+		if l.key == "upload_max_fileparts" {
+			// Uploads are counted in 512 KB parts
+			v = v * 512 / 1024
+		}
+		fmt.Fprintf(b, "\n  %s: %d", l.label, int64(v))
+	}
+}
+
 func handleResolveUsername(_ context.Context, _ mcp.CallToolRequest, input resolveUsernameInput) (*mcp.CallToolResult, error) {
 	tgCtx := services.Context()
 
@@ -196,6 +311,12 @@ func handleGetUser(_ context.Context, _ mcp.CallToolRequest, input getUserInput)
 			continue
 		}
 		formatUser(&b, user)
+		if user.Premium {
+			b.WriteString("Premium: yes\n")
+		}
+		if status, ok := user.GetEmojiStatus(); ok {
+			formatEmojiStatus(&b, status)
+		}
 		break
 	}
 
@@ -263,6 +384,257 @@ func handleSearchContacts(_ context.Context, _ mcp.CallToolRequest, input search
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+func handleSearchContactsLocal(_ context.Context, _ mcp.CallToolRequest, input searchContactsLocalInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	if input.Query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var (
+		users []*tg.User
+		chats []tg.ChatClass
+	)
+	seen := make(map[string]bool)
+	addUser := func(user *tg.User) {
+		key := fmt.Sprintf("user:%d", user.ID)
+		if !seen[key] {
+			seen[key] = true
+			users = append(users, user)
+		}
+	}
+	addChat := func(chat tg.ChatClass) {
+		key := fmt.Sprintf("chat:%d", chat.GetID())
+		if !seen[key] {
+			seen[key] = true
+			chats = append(chats, chat)
+		}
+	}
+
+	// A failed server search still leaves the local results useful
+	var serverErr error
+	found, err := services.API().ContactsSearch(tgCtx, &tg.ContactsSearchRequest{
+		Q:     input.Query,
+		Limit: limit,
+	})
+	if err != nil {
+		serverErr = err
+	} else {
+		services.StorePeers(tgCtx, found.Chats, found.Users)
+		for _, u := range found.Users {
+			if user, ok := u.(*tg.User); ok {
+				addUser(user)
+			}
+		}
+		for _, c := range found.Chats {
+			addChat(c)
+		}
+	}
+	serverCount := len(users) + len(chats)
+
+	local, err := services.SearchLocalPeers(tgCtx, input.Query, limit)
+	if err != nil && serverErr != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("search failed: %v", serverErr)), nil
+	}
+	for _, p := range local {
+		switch {
+		case p.User != nil:
+			addUser(p.User)
+		case p.Channel != nil:
+			addChat(p.Channel)
+		case p.Chat != nil:
+			addChat(p.Chat)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Search results for %q (server: %d, local cache: %d new)\n", input.Query, serverCount, len(users)+len(chats)-serverCount)
+	if serverErr != nil {
+		fmt.Fprintf(&b, "Server search failed: %v\n", serverErr)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "Local search failed: %v\n", err)
+	}
+
+	if len(users) > 0 {
+		fmt.Fprintf(&b, "\nUsers (%d):\n", len(users))
+		for _, user := range users {
+			b.WriteString("\n")
+			formatUser(&b, user)
+		}
+	}
+
+	if len(chats) > 0 {
+		fmt.Fprintf(&b, "\nChats/Channels (%d):\n", len(chats))
+		for _, c := range chats {
+			b.WriteString("\n")
+			formatChat(&b, c)
+		}
+	}
+
+	if len(users) == 0 && len(chats) == 0 {
+		b.WriteString("\nNo results found.")
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+func handleFindUserInChats(_ context.Context, _ mcp.CallToolRequest, input findUserInChatsInput) (*mcp.CallToolResult, error) {
+	tgCtx := services.Context()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	peer, err := services.ResolvePeer(tgCtx, input.UserID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve peer: %v", err)), nil
+	}
+
+	userPeer, ok := peer.(*tg.InputPeerUser)
+	if !ok {
+		return mcp.NewToolResultError("the provided identifier does not resolve to a user"), nil
+	}
+
+	result, err := services.API().MessagesGetDialogs(tgCtx, &tg.MessagesGetDialogsRequest{
+		OffsetPeer: &tg.InputPeerEmpty{},
+		Limit:      limit,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get dialogs: %v", err)), nil
+	}
+
+	modified, ok := result.AsModified()
+	if !ok {
+		return mcp.NewToolResultError("no dialogs returned"), nil
+	}
+
+	chats := modified.GetChats()
+	services.StorePeers(tgCtx, chats, modified.GetUsers())
+
+	var b strings.Builder
+	found, scanned, skipped := 0, 0, 0
+
+	for _, c := range chats {
+		switch chat := c.(type) {
+		case *tg.Channel:
+			scanned++
+			participant, err := services.API().ChannelsGetParticipant(tgCtx, &tg.ChannelsGetParticipantRequest{
+				Channel:     &tg.InputChannel{ChannelID: chat.ID, AccessHash: chat.AccessHash},
+				Participant: peer,
+			})
+			if err != nil {
+				// USER_NOT_PARTICIPANT means not a member; other errors (e.g. no admin rights) are skipped
+				if !tg.IsUserNotParticipant(err) {
+					skipped++
+				}
+				continue
+			}
+			role := participantRole(participant.Participant)
+			if role == "" {
+				continue
+			}
+			found++
+			fmt.Fprintf(&b, "\n  %s (ID: %d) - %s", chat.Title, chat.ID, role)
+		case *tg.Chat:
+			scanned++
+			full, err := services.API().MessagesGetFullChat(tgCtx, chat.ID)
+			if err != nil {
+				skipped++
+				continue
+			}
+			chatFull, ok := full.FullChat.(*tg.ChatFull)
+			if !ok {
+				skipped++
+				continue
+			}
+			participants, ok := chatFull.Participants.(*tg.ChatParticipants)
+			if !ok {
+				skipped++
+				continue
+			}
+			for _, p := range participants.Participants {
+				if p.GetUserID() != userPeer.UserID {
+					continue
+				}
+				found++
+				role := "member"
+				switch p.(type) {
+				case *tg.ChatParticipantCreator:
+					role = "creator"
+				case *tg.ChatParticipantAdmin:
+					role = "admin"
+				}
+				fmt.Fprintf(&b, "\n  %s (ID: %d) - %s", chat.Title, chat.ID, role)
+				break
+			}
+		}
+	}
+
+	header := fmt.Sprintf("Member of %d of %d scanned group(s)/channel(s)", found, scanned)
+	if skipped > 0 {
+		header += fmt.Sprintf(" (%d could not be checked)", skipped)
+	}
+	header += ":"
+	if found == 0 {
+		header += "\n  (none)"
+	}
+
+	return mcp.NewToolResultText(header + b.String()), nil
+}
+
+// participantRole returns a short role label for a channel participant,
+// or an empty string if the user is no longer a member.
+func participantRole(p tg.ChannelParticipantClass) string {
+	switch v := p.(type) {
+	case *tg.ChannelParticipantCreator:
+		return "creator"
+	case *tg.ChannelParticipantAdmin:
+		return "admin"
+	case *tg.ChannelParticipantBanned:
+		if v.Left {
+			return ""
+		}
+		return "restricted"
+	case *tg.ChannelParticipantLeft:
+		return ""
+	default:
+		return "member"
+	}
+}
+
+// participantUserID returns the user ID of a channel participant, or 0 for non-user peers.
+func participantUserID(p tg.ChannelParticipantClass) int64 {
+	switch v := p.(type) {
+	case *tg.ChannelParticipant:
+		return v.UserID
+	case *tg.ChannelParticipantSelf:
+		return v.UserID
+	case *tg.ChannelParticipantCreator:
+		return v.UserID
+	case *tg.ChannelParticipantAdmin:
+		return v.UserID
+	case *tg.ChannelParticipantBanned:
+		return peerToID(v.Peer)
+	case *tg.ChannelParticipantLeft:
+		return peerToID(v.Peer)
+	default:
+		return 0
+	}
+}
+
 func toInputUser(p tg.InputPeerClass) (*tg.InputUser, bool) {
 	u, ok := p.(*tg.InputPeerUser)
 	if !ok {
@@ -286,6 +658,37 @@ func formatUser(b *strings.Builder, user *tg.User) {
 	if user.Bot {
 		b.WriteString("Type: Bot\n")
 	}
+	formatTrustFlags(b, user.Verified, user.Scam, user.Fake, user.Restricted)
+}
+
+// formatTrustFlags writes the verification and warning flags Telegram attaches
+// to users and channels, so official and suspicious accounts stand out.
+func formatTrustFlags(b *strings.Builder, verified, scam, fake, restricted bool) {
+	if verified {
+		b.WriteString("Verified: yes\n")
+	}
+	if scam {
+		b.WriteString("WARNING: marked as scam by Telegram\n")
+	}
+	if fake {
+		b.WriteString("WARNING: marked as fake by Telegram\n")
+	}
+	if restricted {
+		b.WriteString("Restricted: yes\n")
+	}
+}
+
+func formatEmojiStatus(b *strings.Builder, status tg.EmojiStatusClass) {
+	switch st := status.(type) {
+	case *tg.EmojiStatus:
+		fmt.Fprintf(b, "Emoji Status: %d", st.DocumentID)
+		if until, ok := st.GetUntil(); ok {
+			fmt.Fprintf(b, " (until %s)", time.Unix(int64(until), 0).UTC().Format("2006-01-02 15:04:05"))
+		}
+		b.WriteString("\n")
+	case *tg.EmojiStatusCollectible:
+		fmt.Fprintf(b, "Emoji Status: %d (collectible: %s)\n", st.DocumentID, st.Title)
+	}
 }
 
 func formatChat(b *strings.Builder, chat tg.ChatClass) {
@@ -307,5 +710,6 @@ func formatChat(b *strings.Builder, chat tg.ChatClass) {
 		if c.ParticipantsCount != 0 {
 			fmt.Fprintf(b, "Members: %d\n", c.ParticipantsCount)
 		}
+		formatTrustFlags(b, c.Verified, c.Scam, c.Fake, c.Restricted)
 	}
 }